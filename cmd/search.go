@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	v1BoardService "github.com/go-funcards/funapi/internal/client/board_service/v1"
+	v1CardService "github.com/go-funcards/funapi/internal/client/card_service/v1"
+	v1CategoryService "github.com/go-funcards/funapi/internal/client/category_service/v1"
+	"github.com/go-funcards/funapi/internal/client/interceptor"
+	v1TagService "github.com/go-funcards/funapi/internal/client/tag_service/v1"
+	"github.com/go-funcards/funapi/internal/config"
+	"github.com/go-funcards/funapi/internal/search"
+	v1Board "github.com/go-funcards/funapi/proto/board_service/v1"
+	"github.com/go-redis/redis/v8"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const rebuildPageSize = 100
+
+var searchCmd = &cobra.Command{
+	Use:   "search",
+	Short: "Manage search index",
+	Long:  "Manage search index of cards, categories and tags",
+}
+
+var searchRebuildCmd = &cobra.Command{
+	Use:   "rebuild",
+	Short: "Rebuild search index",
+	Long: "Reindex cards, categories and tags of all boards and remove boards that no longer exist. " +
+		"Boards are reindexed one by one in place, so search keeps working during the rebuild. " +
+		"A document changed while its board is reindexed may be indexed stale until it is changed again.",
+	RunE: executeSearchRebuildCommand,
+}
+
+func init() {
+	searchCmd.AddCommand(searchRebuildCmd)
+	rootCmd.AddCommand(searchCmd)
+}
+
+func executeSearchRebuildCommand(cmd *cobra.Command, _ []string) error {
+	cfg, err := config.GetConfig(globalFlags.ConfigFile)
+	if err != nil {
+		return err
+	}
+
+	logger, err := cfg.Log.BuildLogger(cfg.Debug)
+	if err != nil {
+		return err
+	}
+	logger.Debug("config and logger initialized")
+
+	logger.Debug("parsing redis url")
+	opt, err := redis.ParseURL(cfg.Redis.URI)
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("initializing redis client")
+	rdb := redis.NewClient(opt)
+	defer rdb.Close()
+
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(
+			interceptor.UnaryClientMetadata(),
+			grpc_zap.UnaryClientInterceptor(logger),
+		),
+	}
+
+	boardPool, err := cfg.Services.Board.NewPool(cmd.Context(), opts...)
+	if err != nil {
+		return err
+	}
+	defer boardPool.Close()
+
+	tagPool, err := cfg.Services.Tag.NewPool(cmd.Context(), opts...)
+	if err != nil {
+		return err
+	}
+	defer tagPool.Close()
+
+	categoryPool, err := cfg.Services.Category.NewPool(cmd.Context(), opts...)
+	if err != nil {
+		return err
+	}
+	defer categoryPool.Close()
+
+	cardPool, err := cfg.Services.Card.NewPool(cmd.Context(), opts...)
+	if err != nil {
+		return err
+	}
+	defer cardPool.Close()
+
+	boardService := &v1BoardService.BoardService{Pool: boardPool}
+	sources := search.Sources{
+		CategoryService: &v1CategoryService.CategoryService{Pool: categoryPool},
+		CardService:     &v1CardService.CardService{Pool: cardPool},
+		TagService:      &v1TagService.TagService{Pool: tagPool},
+	}
+	index := &search.Index{Redis: rdb, Log: logger}
+
+	ctx := cmd.Context()
+
+	indexed, err := index.Boards(ctx)
+	if err != nil {
+		return err
+	}
+
+	visited := make(map[string]bool)
+	var boards, docs int
+	req := &v1Board.BoardsRequest{PageSize: rebuildPageSize}
+	for ; ; req.PageIndex++ {
+		response, err := boardService.GetBoards(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		for _, board := range response.GetBoards() {
+			boardDocs, err := sources.BoardDocuments(ctx, board.GetBoardId())
+			if err != nil {
+				return err
+			}
+			if err = index.ReplaceBoard(ctx, board.GetBoardId(), boardDocs); err != nil {
+				return err
+			}
+			visited[board.GetBoardId()] = true
+			boards++
+			docs += len(boardDocs)
+		}
+
+		if len(response.GetBoards()) < rebuildPageSize {
+			break
+		}
+	}
+
+	var removed int
+	for _, boardID := range indexed {
+		if visited[boardID] {
+			continue
+		}
+		if err = index.RemoveBoard(ctx, boardID); err != nil {
+			return err
+		}
+		removed++
+	}
+
+	logger.Info("search index rebuilt", zap.Int("boards", boards), zap.Int("documents", docs), zap.Int("removed", removed))
+	return nil
+}
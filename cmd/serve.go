@@ -2,15 +2,18 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/gin-contrib/zap"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-funcards/funapi/docs"
+	"github.com/go-funcards/funapi/internal/authzcache"
 	v1AuthzService "github.com/go-funcards/funapi/internal/client/authz_service/v1"
 	v1BoardService "github.com/go-funcards/funapi/internal/client/board_service/v1"
 	v1CardService "github.com/go-funcards/funapi/internal/client/card_service/v1"
 	v1CategoryService "github.com/go-funcards/funapi/internal/client/category_service/v1"
+	"github.com/go-funcards/funapi/internal/client/interceptor"
 	v1TagService "github.com/go-funcards/funapi/internal/client/tag_service/v1"
 	v1UserService "github.com/go-funcards/funapi/internal/client/user_service/v1"
 	"github.com/go-funcards/funapi/internal/config"
@@ -20,12 +23,22 @@ import (
 	"github.com/go-funcards/funapi/internal/handlers/v1/boards"
 	"github.com/go-funcards/funapi/internal/handlers/v1/cards"
 	"github.com/go-funcards/funapi/internal/handlers/v1/categories"
+	"github.com/go-funcards/funapi/internal/handlers/v1/clientutil"
+	"github.com/go-funcards/funapi/internal/handlers/v1/invitations"
 	"github.com/go-funcards/funapi/internal/handlers/v1/members"
+	searchHandlers "github.com/go-funcards/funapi/internal/handlers/v1/search"
 	"github.com/go-funcards/funapi/internal/handlers/v1/session"
 	"github.com/go-funcards/funapi/internal/handlers/v1/tags"
+	trashHandlers "github.com/go-funcards/funapi/internal/handlers/v1/trash"
 	"github.com/go-funcards/funapi/internal/handlers/v1/users"
+	"github.com/go-funcards/funapi/internal/invitation"
+	"github.com/go-funcards/funapi/internal/jobs"
+	"github.com/go-funcards/funapi/internal/search"
+	"github.com/go-funcards/funapi/internal/throttle"
+	"github.com/go-funcards/funapi/internal/tokenstore"
+	"github.com/go-funcards/funapi/internal/trash"
 	"github.com/go-funcards/graceful"
-	"github.com/go-funcards/token-redis"
+	"github.com/go-funcards/token"
 	"github.com/go-funcards/validate"
 	"github.com/go-playground/validator/v10"
 	"github.com/go-redis/redis/v8"
@@ -70,6 +83,9 @@ func init() {
 			}
 			return name
 		})
+		if err := httputil.RegisterSortValidation(v); err != nil {
+			panic(err)
+		}
 	}
 }
 
@@ -106,7 +122,10 @@ func executeServeCommand(cmd *cobra.Command, _ []string) error {
 
 	opts := []grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithChainUnaryInterceptor(grpc_zap.UnaryClientInterceptor(logger)),
+		grpc.WithChainUnaryInterceptor(
+			interceptor.UnaryClientMetadata(),
+			grpc_zap.UnaryClientInterceptor(logger),
+		),
 	}
 
 	authzPool, err := cfg.Services.Authz.NewPool(cmd.Context(), opts...)
@@ -156,15 +175,65 @@ func executeServeCommand(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
+	logger.Debug("initializing notifier")
+	notifier, err := cfg.Notify.Notifier(logger)
+	if err != nil {
+		return err
+	}
+
 	logger.Debug("initializing token service")
-	tokenService := tokenredis.New(cfg.RefreshToken, generator, rdb)
+	denyList := &tokenstore.DenyList{Redis: rdb, TTL: cfg.JWT.Signer.TTL}
+	tokenStorage := tokenstore.NewStorage(rdb, cfg.RefreshToken.TTL, denyList)
+	tokenService := token.New(cfg.RefreshToken, denyList.Generator(generator), tokenStorage)
+
+	authorize := middleware.Authorize(verifier, denyList, cfg.RefreshToken.TokenType)
+
+	verifications := &tokenstore.Verifications{
+		Redis:  rdb,
+		Tokens: &tokenstore.OneTime{Redis: rdb, Prefix: "email_verification", TTL: cfg.EmailVerification.TTL},
+		Since:  cfg.EmailVerification.Since,
+	}
+	requireVerified, err := middleware.RequireVerified(verifications, cfg.EmailVerification.Restrict)
+	if err != nil {
+		return err
+	}
+	// without it every user registered before verification was introduced would be restricted
+	if cfg.EmailVerification.Restrict != middleware.RestrictNone && cfg.EmailVerification.Since.IsZero() {
+		return errors.New("email verification since must be set when unverified users are restricted")
+	}
+
+	cursorSecret := []byte(cfg.Cursor.Secret)
+	if len(cursorSecret) == 0 {
+		logger.Warn("cursor secret is not configured, cursors are accepted only by this instance until it restarts")
+		if cursorSecret, err = httputil.RandomCursorSecret(); err != nil {
+			return err
+		}
+	}
+	httputil.SetCursorSecret(cursorSecret)
 
-	authorize := middleware.Authorize(verifier, cfg.RefreshToken.TokenType)
+	limiter := &throttle.Limiter{Redis: rdb, Config: cfg.RateLimit, Log: logger}
+	rateLimit := func(group string, key middleware.RateLimitKeyFn) gin.HandlerFunc {
+		return middleware.RateLimit(limiter, group, key)
+	}
+
+	trashStore := &trash.Store{Redis: rdb, Retention: cfg.Trash.Retention, Lease: cfg.Trash.Lease}
+	searchIndex := &search.Index{Redis: rdb, Log: logger}
+	invitationStore := &invitation.Store{Redis: rdb, TTL: cfg.Invitation.TTL}
+
+	decisions := authzcache.New(cfg.AuthzCache, rdb, logger)
 
-	subjectService := &v1AuthzService.SubjectService{Pool: authzPool}
-	checkerService := &v1AuthzService.CheckerService{Pool: authzPool}
+	subjectService := &authzcache.Subjects{SubjectClient: &v1AuthzService.SubjectService{Pool: authzPool}, Cache: decisions}
+	checkerService := &authzcache.Checker{AuthorizationCheckerClient: &v1AuthzService.CheckerService{Pool: authzPool}, Cache: decisions}
+	batchChecker := &v1AuthzService.BatchChecker{Client: checkerService}
 	userService := &v1UserService.UserService{Pool: userPool}
-	boardService := &v1BoardService.BoardService{Pool: boardPool}
+	verifications.Registered = func(ctx context.Context, userID string) (time.Time, error) {
+		user, err := clientutil.GetUser(ctx, userService, userID)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return user.GetCreatedAt().AsTime(), nil
+	}
+	boardService := &authzcache.Boards{BoardClient: &v1BoardService.BoardService{Pool: boardPool}, Cache: decisions}
 	tagService := &v1TagService.TagService{Pool: tagPool}
 	categoryService := &v1CategoryService.CategoryService{Pool: categoryPool}
 	cardService := &v1CardService.CardService{Pool: cardPool}
@@ -173,21 +242,34 @@ func executeServeCommand(cmd *cobra.Command, _ []string) error {
 		UserService:    userService,
 		SubjectService: subjectService,
 		TokenService:   tokenService,
+		LoginThrottle:  &throttle.Login{Redis: rdb, Config: cfg.LoginThrottle},
+		TokenStorage:   tokenStorage,
+		DenyList:       denyList,
+		PasswordResets: &tokenstore.OneTime{Redis: rdb, Prefix: "password_reset", TTL: cfg.PasswordReset.TTL},
+		ResetURL:       cfg.PasswordReset.URL,
+		Verifications:  verifications,
+		VerifyURL:      cfg.EmailVerification.URL,
+		Notifier:       notifier,
+		Authorize:      authorize,
 		Log:            logger,
 	}
 
 	userHandler := &users.Handler{
-		UserService: userService,
-		IsGranted:   httputil.IsGranted(checkerService, "USER"),
-		Log:         logger,
+		UserService:  userService,
+		TokenStorage: tokenStorage,
+		Session:      sessionHandler,
+		IsGranted:    httputil.IsGranted(checkerService, "USER"),
+		Log:          logger,
 	}
 
 	tagHandler := &tags.Handler{
 		BaseBoard: &handlers.BaseBoard{
 			BoardService: boardService,
 			IsGrantedFn:  httputil.IsGranted(checkerService, "TAG"),
+			AreGrantedFn: httputil.AreGranted(batchChecker, "TAG"),
 		},
 		TagService: tagService,
+		Search:     searchIndex,
 		Log:        logger,
 	}
 
@@ -195,8 +277,11 @@ func executeServeCommand(cmd *cobra.Command, _ []string) error {
 		BaseBoard: &handlers.BaseBoard{
 			BoardService: boardService,
 			IsGrantedFn:  httputil.IsGranted(checkerService, "CATEGORY"),
+			AreGrantedFn: httputil.AreGranted(batchChecker, "CATEGORY"),
 		},
 		CategoryService: categoryService,
+		Search:          searchIndex,
+		Trash:           trashStore,
 		Log:             logger,
 	}
 
@@ -204,17 +289,33 @@ func executeServeCommand(cmd *cobra.Command, _ []string) error {
 		BaseBoard: &handlers.BaseBoard{
 			BoardService: boardService,
 			IsGrantedFn:  httputil.IsGranted(checkerService, "CARD"),
+			AreGrantedFn: httputil.AreGranted(batchChecker, "CARD"),
 		},
-		CardService: cardService,
-		Log:         logger,
+		CardService:     cardService,
+		CategoryService: categoryService,
+		Search:          searchIndex,
+		Trash:           trashStore,
+		Log:             logger,
+	}
+
+	boardDeletion := &jobs.BoardDeletion{
+		Redis:           rdb,
+		CardService:     cardService,
+		CategoryService: categoryService,
+		TagService:      tagService,
+		Search:          searchIndex,
+		Config:          cfg.BoardDeletion,
+		Log:             logger,
 	}
 
 	memberHandler := &members.Handler{
 		BaseBoard: &handlers.BaseBoard{
 			BoardService: boardService,
 			IsGrantedFn:  httputil.IsGranted(checkerService, "BOARD"),
+			AreGrantedFn: httputil.AreGranted(batchChecker, "BOARD"),
 		},
 		SubjectService: subjectService,
+		UserService:    userService,
 		Log:            logger,
 	}
 
@@ -222,26 +323,87 @@ func executeServeCommand(cmd *cobra.Command, _ []string) error {
 		BaseBoard: &handlers.BaseBoard{
 			BoardService: boardService,
 			IsGrantedFn:  memberHandler.IsGrantedFn,
+			AreGrantedFn: memberHandler.AreGrantedFn,
 		},
-		MemberHandler:  memberHandler,
-		SubjectService: subjectService,
-		Log:            logger,
+		MemberHandler:        memberHandler,
+		CategoryOrderHandler: &categories.OrderHandler{Handler: categoryHandler},
+		Categories:           categoryHandler.BaseBoard,
+		Cards:                cardHandler.BaseBoard,
+		SubjectService:       subjectService,
+		CategoryService:      categoryService,
+		CardService:          cardService,
+		TagService:           tagService,
+		Trash:                trashStore,
+		Deletion:             boardDeletion,
+		Log:                  logger,
+	}
+
+	trashHandler := &trashHandlers.Handler{
+		BoardService:    boardService,
+		CategoryService: categoryService,
+		CardService:     cardService,
+		TagService:      tagService,
+		SubjectService:  subjectService,
+		Categories:      categoryHandler.BaseBoard,
+		Cards:           cardHandler.BaseBoard,
+		Trash:           trashStore,
+		Deletion:        boardDeletion,
+		Search:          searchIndex,
+		Log:             logger,
+	}
+
+	invitationHandler := &invitations.Handler{
+		BaseBoard:     memberHandler.BaseBoard,
+		IsGrantedToFn: httputil.IsGrantedTo(checkerService, "BOARD"),
+		Members:       memberHandler,
+		UserService:   userService,
+		Invitations:   invitationStore,
+		Verifications: verifications,
+		Notifier:      notifier,
+		URL:           cfg.Invitation.URL,
+		Log:           logger,
 	}
 
+	searchHandler := &searchHandlers.Handler{
+		BoardService: boardService,
+		Cards:        cardHandler.BaseBoard,
+		Categories:   categoryHandler.BaseBoard,
+		Tags:         tagHandler.BaseBoard,
+		Index:        searchIndex,
+		Log:          logger,
+	}
+
+	jobsCtx, cancelJobs := context.WithCancel(cmd.Context())
+	defer cancelJobs()
+
+	logger.Debug("starting authz cache invalidation listener")
+	go decisions.Run(jobsCtx)
+
+	logger.Debug("starting trash purge")
+	go (&jobs.Purge{
+		Trash:         trashStore,
+		BoardDeletion: boardDeletion,
+		Interval:      cfg.Trash.PurgeInterval,
+		Log:           logger,
+	}).Run(jobsCtx)
+
 	r := router(cfg.Debug, logger)
-	api := r.Group("/api")
+	api := r.Group("/api", middleware.Timeout(cfg.Timeout.Default, cfg.Timeout.Routes))
 	{
 		v1 := api.Group("/v1")
 		{
-			sessionHandler.Register(v1)
+			sessionHandler.Register(v1.Group("", rateLimit("session", middleware.ByClientIP)))
 
-			authorized := v1.Group("", authorize)
+			authorized := v1.Group("", authorize, requireVerified)
 			{
-				userHandler.Register(authorized)
-				boardHandler.Register(authorized)
-				tagHandler.Register(authorized)
-				categoryHandler.Register(authorized)
-				cardHandler.Register(authorized)
+				userHandler.Register(authorized.Group("", rateLimit("users", middleware.ByUserID)))
+				boardHandler.Register(authorized.Group("", rateLimit("boards", middleware.ByUserID)))
+				tagHandler.Register(authorized.Group("", rateLimit("tags", middleware.ByUserID)))
+				categoryHandler.Register(authorized.Group("", rateLimit("categories", middleware.ByUserID)))
+				cardHandler.Register(authorized.Group("", rateLimit("cards", middleware.ByUserID)))
+				trashHandler.Register(authorized.Group("", rateLimit("trash", middleware.ByUserID)))
+				searchHandler.Register(authorized.Group("", rateLimit("search", middleware.ByUserID)))
+				invitationHandler.Register(authorized.Group("", rateLimit("invitations", middleware.ByUserID)))
 			}
 		}
 	}
@@ -266,6 +428,7 @@ func router(debug bool, logger *zap.Logger) *gin.Engine {
 
 	r := gin.New()
 	r.HandleMethodNotAllowed = true
+	r.Use(middleware.RequestID())
 	r.Use(ginzap.Ginzap(logger, time.RFC3339, true))
 	r.Use(ginzap.RecoveryWithZap(logger, true))
 	r.Use(middleware.APIError())
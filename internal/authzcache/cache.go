@@ -0,0 +1,153 @@
+package authzcache
+
+import (
+	"context"
+	"encoding/binary"
+	"github.com/go-redis/redis/v8"
+	"go.uber.org/zap"
+	"time"
+)
+
+const invalidationChannel = "authz_cache_invalidations"
+
+type Config struct {
+	Enable bool          `yaml:"enable" env:"ENABLE" env-default:"true"`
+	Size   int           `yaml:"size" env:"SIZE" env-default:"10000"`
+	TTL    time.Duration `yaml:"ttl" env:"TTL" env-default:"10s"`
+	// Redis shares cached decisions and invalidations between gateway instances. Without it,
+	// an instance may serve a decision invalidated on another instance until the TTL expires.
+	Redis bool `yaml:"redis" env:"REDIS" env-default:"false"`
+}
+
+// Cache keeps authorization decisions and boards for a short TTL in an in-process LRU,
+// optionally backed by Redis. Entries are grouped by scope, the ID of the board they depend on,
+// so that all of them are invalidated at once. A nil Cache caches nothing.
+type Cache struct {
+	redis *redis.Client
+	ttl   time.Duration
+	local *lru
+	log   *zap.Logger
+}
+
+// New returns the cache, or nil when it is disabled.
+func New(cfg Config, rdb *redis.Client, log *zap.Logger) *Cache {
+	if !cfg.Enable || cfg.Size <= 0 || cfg.TTL <= 0 {
+		return nil
+	}
+	c := &Cache{
+		ttl:   cfg.TTL,
+		local: newLRU(cfg.Size),
+		log:   log,
+	}
+	if cfg.Redis {
+		c.redis = rdb
+	}
+	return c
+}
+
+func (c *Cache) Get(ctx context.Context, scope, key string) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	now := time.Now()
+	if value, ok := c.local.get(scope, key, now); ok {
+		return value, true
+	}
+	if c.redis == nil {
+		return nil, false
+	}
+
+	data, err := c.redis.HGet(ctx, redisKey(scope), key).Bytes()
+	if err != nil {
+		if err != redis.Nil {
+			c.log.Warn("authz cache::get", zap.Error(err))
+		}
+		return nil, false
+	}
+	if len(data) < 8 {
+		return nil, false
+	}
+	expires := time.UnixMilli(int64(binary.BigEndian.Uint64(data)))
+	if !now.Before(expires) {
+		return nil, false
+	}
+
+	value := data[8:]
+	c.local.set(scope, key, value, expires)
+	return value, true
+}
+
+func (c *Cache) Set(ctx context.Context, scope, key string, value []byte) {
+	if c == nil {
+		return
+	}
+
+	expires := time.Now().Add(c.ttl)
+	c.local.set(scope, key, value, expires)
+	if c.redis == nil {
+		return
+	}
+
+	data := make([]byte, 8, 8+len(value))
+	binary.BigEndian.PutUint64(data, uint64(expires.UnixMilli()))
+	data = append(data, value...)
+
+	_, err := c.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, redisKey(scope), key, data)
+		pipe.PExpire(ctx, redisKey(scope), c.ttl)
+		return nil
+	})
+	if err != nil {
+		c.log.Warn("authz cache::set", zap.Error(err))
+	}
+}
+
+// Invalidate removes all entries of the scope, from other gateway instances too when backed by Redis.
+func (c *Cache) Invalidate(ctx context.Context, scope string) {
+	if c == nil {
+		return
+	}
+
+	c.local.removeScope(scope)
+	if c.redis == nil {
+		return
+	}
+
+	_, err := c.redis.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, redisKey(scope))
+		pipe.Publish(ctx, invalidationChannel, scope)
+		return nil
+	})
+	if err != nil {
+		c.log.Error("authz cache::invalidate", zap.String("scope", scope), zap.Error(err))
+	}
+}
+
+// Run applies invalidations published by other gateway instances until the context is done.
+// It returns immediately when the cache is not backed by Redis.
+func (c *Cache) Run(ctx context.Context) {
+	if c == nil || c.redis == nil {
+		return
+	}
+
+	sub := c.redis.Subscribe(ctx, invalidationChannel)
+	defer sub.Close()
+
+	ch := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			c.local.removeScope(msg.Payload)
+		}
+	}
+}
+
+func redisKey(scope string) string {
+	return "authz_cache:" + scope
+}
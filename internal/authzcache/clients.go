@@ -0,0 +1,127 @@
+package authzcache
+
+import (
+	"context"
+	"encoding/json"
+	v1Authz "github.com/go-funcards/funapi/proto/authz_service/v1"
+	v1Board "github.com/go-funcards/funapi/proto/board_service/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"strings"
+)
+
+const boardKey = "board"
+
+var (
+	_ v1Authz.AuthorizationCheckerClient = (*Checker)(nil)
+	_ v1Board.BoardClient                = (*Boards)(nil)
+	_ v1Authz.SubjectClient              = (*Subjects)(nil)
+)
+
+// Checker caches decisions of the checker, keyed by subject, object and action,
+// in the scope of the board referenced by the object.
+type Checker struct {
+	v1Authz.AuthorizationCheckerClient
+	Cache *Cache
+}
+
+func (s *Checker) IsGranted(ctx context.Context, in *v1Authz.IsGrantedRequest, opts ...grpc.CallOption) (*v1Authz.Granted, error) {
+	params := in.GetParams()
+	if len(params) != 3 {
+		return s.AuthorizationCheckerClient.IsGranted(ctx, in, opts...)
+	}
+
+	scope, key := objectRef(params[1]), strings.Join(params, "\x00")
+	if value, ok := s.Cache.Get(ctx, scope, key); ok {
+		return &v1Authz.Granted{Yes: string(value) == "1"}, nil
+	}
+
+	granted, err := s.AuthorizationCheckerClient.IsGranted(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	value := "0"
+	if granted.GetYes() {
+		value = "1"
+	}
+	s.Cache.Set(ctx, scope, key, []byte(value))
+
+	return granted, nil
+}
+
+// Boards caches boards read one by one by ID, as clientutil.GetBoard does, and invalidates
+// the scope of a board when it is updated or deleted.
+type Boards struct {
+	v1Board.BoardClient
+	Cache *Cache
+}
+
+func (s *Boards) GetBoards(ctx context.Context, in *v1Board.BoardsRequest, opts ...grpc.CallOption) (*v1Board.BoardsResponse, error) {
+	if len(in.GetBoardIds()) != 1 || in.GetPageIndex() != 0 || len(in.GetTypes()) > 0 || len(in.GetOwnerIds()) > 0 || len(in.GetMemberIds()) > 0 {
+		return s.BoardClient.GetBoards(ctx, in, opts...)
+	}
+
+	boardID := in.GetBoardIds()[0]
+	if value, ok := s.Cache.Get(ctx, boardID, boardKey); ok {
+		response := new(v1Board.BoardsResponse)
+		if proto.Unmarshal(value, response) == nil {
+			return response, nil
+		}
+	}
+
+	response, err := s.BoardClient.GetBoards(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(response.GetBoards()) == 1 {
+		if value, err := proto.Marshal(response); err == nil {
+			s.Cache.Set(ctx, boardID, boardKey, value)
+		}
+	}
+
+	return response, nil
+}
+
+func (s *Boards) UpdateBoard(ctx context.Context, in *v1Board.UpdateBoardRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	defer s.Cache.Invalidate(ctx, in.GetBoardId())
+	return s.BoardClient.UpdateBoard(ctx, in, opts...)
+}
+
+func (s *Boards) DeleteBoard(ctx context.Context, in *v1Board.DeleteBoardRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	defer s.Cache.Invalidate(ctx, in.GetBoardId())
+	return s.BoardClient.DeleteBoard(ctx, in, opts...)
+}
+
+// Subjects invalidates the scopes of boards whose members are saved or deleted.
+type Subjects struct {
+	v1Authz.SubjectClient
+	Cache *Cache
+}
+
+func (s *Subjects) SaveSub(ctx context.Context, in *v1Authz.SaveSubRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	defer func() {
+		for _, ref := range in.GetRefs() {
+			s.Cache.Invalidate(ctx, ref.GetRefId())
+		}
+	}()
+	return s.SubjectClient.SaveSub(ctx, in, opts...)
+}
+
+func (s *Subjects) DeleteRef(ctx context.Context, in *v1Authz.DeleteRefRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	defer s.Cache.Invalidate(ctx, in.GetRefId())
+	return s.SubjectClient.DeleteRef(ctx, in, opts...)
+}
+
+// objectRef returns the ref of an object built by NewObject, the board ID for board objects.
+func objectRef(obj string) string {
+	var o struct {
+		Ref string `json:"ref"`
+	}
+	if json.Unmarshal([]byte(obj), &o) != nil {
+		return ""
+	}
+	return o.Ref
+}
@@ -0,0 +1,88 @@
+package authzcache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type entry struct {
+	scope, key string
+	value      []byte
+	expires    time.Time
+}
+
+// lru is an in-process LRU of entries expiring after their TTL, grouped by scope.
+type lru struct {
+	mu     sync.Mutex
+	size   int
+	ll     *list.List
+	scopes map[string]map[string]*list.Element
+}
+
+func newLRU(size int) *lru {
+	return &lru{
+		size:   size,
+		ll:     list.New(),
+		scopes: make(map[string]map[string]*list.Element),
+	}
+}
+
+func (l *lru) get(scope, key string, now time.Time) ([]byte, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	el, ok := l.scopes[scope][key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*entry)
+	if !now.Before(e.expires) {
+		l.remove(el)
+		return nil, false
+	}
+	l.ll.MoveToFront(el)
+	return e.value, true
+}
+
+func (l *lru) set(scope, key string, value []byte, expires time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if el, ok := l.scopes[scope][key]; ok {
+		e := el.Value.(*entry)
+		e.value, e.expires = value, expires
+		l.ll.MoveToFront(el)
+		return
+	}
+
+	keys, ok := l.scopes[scope]
+	if !ok {
+		keys = make(map[string]*list.Element)
+		l.scopes[scope] = keys
+	}
+	keys[key] = l.ll.PushFront(&entry{scope: scope, key: key, value: value, expires: expires})
+
+	for l.ll.Len() > l.size {
+		l.remove(l.ll.Back())
+	}
+}
+
+func (l *lru) removeScope(scope string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, el := range l.scopes[scope] {
+		l.ll.Remove(el)
+	}
+	delete(l.scopes, scope)
+}
+
+func (l *lru) remove(el *list.Element) {
+	e := l.ll.Remove(el).(*entry)
+	keys := l.scopes[e.scope]
+	delete(keys, e.key)
+	if len(keys) == 0 {
+		delete(l.scopes, e.scope)
+	}
+}
@@ -0,0 +1,89 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-funcards/funapi/proto/authz_service/v1"
+	"sync"
+)
+
+const defaultConcurrency = 8
+
+// ErrUnavailable is wrapped by errors of checks which could not be made,
+// as opposed to denied checks.
+var ErrUnavailable = errors.New("authz service unavailable")
+
+// Tuple is a subject, object and action to check.
+type Tuple struct {
+	Sub string
+	Obj string
+	Act string
+}
+
+// Decision is the result of a check, Err wraps ErrUnavailable when the check could not be made.
+type Decision struct {
+	Granted bool
+	Err     error
+}
+
+// Check checks a single tuple, unlike IsGranted it tells a denied check from a failed one.
+func Check(ctx context.Context, client v1.AuthorizationCheckerClient, sub, obj, act string) (bool, error) {
+	result, err := client.IsGranted(ctx, Is(sub, obj, act))
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	return result.GetYes(), nil
+}
+
+// BatchChecker checks many tuples concurrently, checking each distinct tuple once.
+type BatchChecker struct {
+	Client v1.AuthorizationCheckerClient
+	// Concurrency bounds concurrent checks of a batch, 8 when not set
+	Concurrency int
+}
+
+// Check returns decisions in the order of the tuples.
+func (b *BatchChecker) Check(ctx context.Context, tuples []Tuple) []Decision {
+	index := make(map[Tuple]int, len(tuples))
+	distinct := make([]Tuple, 0, len(tuples))
+	for _, t := range tuples {
+		if _, ok := index[t]; !ok {
+			index[t] = len(distinct)
+			distinct = append(distinct, t)
+		}
+	}
+
+	concurrency := b.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	results := make([]Decision, len(distinct))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, t := range distinct {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, t Tuple) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := ctx.Err(); err != nil {
+				results[i] = Decision{Err: fmt.Errorf("%w: %v", ErrUnavailable, err)}
+				return
+			}
+			granted, err := Check(ctx, b.Client, t.Sub, t.Obj, t.Act)
+			results[i] = Decision{Granted: granted, Err: err}
+		}(i, t)
+	}
+	wg.Wait()
+
+	decisions := make([]Decision, len(tuples))
+	for i, t := range tuples {
+		decisions[i] = results[index[t]]
+	}
+	return decisions
+}
@@ -27,14 +27,27 @@ type paramObject struct {
 	Name  string `json:"name"`
 	Owner string `json:"owner,omitempty"`
 	Ref   string `json:"ref,omitempty"`
+	Child *Child `json:"child,omitempty"`
 }
 
-func NewObject(name, owner, ref string) string {
-	data, err := json.Marshal(paramObject{
+// Child is an item of the referenced object, e.g. a card or category of a board,
+// so that policies can grant actions per item.
+type Child struct {
+	ID    string `json:"id"`
+	Owner string `json:"owner,omitempty"`
+}
+
+// NewObject returns the object of a check, child is optional and only the first one is used.
+func NewObject(name, owner, ref string, child ...Child) string {
+	obj := paramObject{
 		Name:  name,
 		Owner: owner,
 		Ref:   ref,
-	})
+	}
+	if len(child) > 0 {
+		obj.Child = &child[0]
+	}
+	data, err := json.Marshal(obj)
 	if err != nil {
 		return ""
 	}
@@ -0,0 +1,60 @@
+package interceptor
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	MDAuthorization = "authorization"
+	MDUserID        = "x-user-id"
+	MDRequestID     = "x-request-id"
+	MDClientIP      = "x-real-ip"
+)
+
+// Metadata describes the caller of the gateway and is forwarded to every downstream service.
+type Metadata struct {
+	AccessToken string
+	UserID      string
+	RequestID   string
+	ClientIP    string
+}
+
+func (md Metadata) pairs() []string {
+	kv := make([]string, 0, 8)
+	for k, v := range map[string]string{
+		MDAuthorization: md.AccessToken,
+		MDUserID:        md.UserID,
+		MDRequestID:     md.RequestID,
+		MDClientIP:      md.ClientIP,
+	} {
+		if len(v) > 0 {
+			kv = append(kv, k, v)
+		}
+	}
+	return kv
+}
+
+type metadataKey struct{}
+
+func WithMetadata(ctx context.Context, md Metadata) context.Context {
+	return context.WithValue(ctx, metadataKey{}, md)
+}
+
+func MetadataFromContext(ctx context.Context) (Metadata, bool) {
+	md, ok := ctx.Value(metadataKey{}).(Metadata)
+	return md, ok
+}
+
+// UnaryClientMetadata appends the caller metadata stored in the context to outgoing gRPC metadata.
+func UnaryClientMetadata() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if md, ok := MetadataFromContext(ctx); ok {
+			if kv := md.pairs(); len(kv) > 0 {
+				ctx = metadata.AppendToOutgoingContext(ctx, kv...)
+			}
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
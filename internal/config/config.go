@@ -3,12 +3,19 @@ package config
 import (
 	"context"
 	"github.com/go-funcards/envconfig"
+	"github.com/go-funcards/funapi/internal/authzcache"
+	"github.com/go-funcards/funapi/internal/invitation"
+	"github.com/go-funcards/funapi/internal/jobs"
+	"github.com/go-funcards/funapi/internal/notify"
+	"github.com/go-funcards/funapi/internal/throttle"
+	"github.com/go-funcards/funapi/internal/trash"
 	"github.com/go-funcards/grpc-pool"
 	"github.com/go-funcards/jwt"
 	"github.com/go-funcards/logger"
 	"github.com/go-funcards/token"
 	"google.golang.org/grpc"
 	"sync"
+	"time"
 )
 
 type ServiceConfig struct {
@@ -44,15 +51,57 @@ type ServerConfig struct {
 	Addr string `yaml:"address" env:"ADDRESS" env-default:":80"`
 }
 
+// TimeoutConfig holds deadlines of downstream gRPC calls made while serving a request.
+// Routes are keyed by "METHOD /full/path", e.g. "GET /api/v1/boards/:board_id".
+type TimeoutConfig struct {
+	Default time.Duration            `yaml:"default" env:"DEFAULT" env-default:"10s"`
+	Routes  map[string]time.Duration `yaml:"routes"`
+}
+
+type PasswordResetConfig struct {
+	TTL time.Duration `yaml:"ttl" env:"TTL" env-default:"30m"`
+	// URL of the client page resetting the password, the token is appended as "token" query parameter
+	URL string `yaml:"url" env:"URL"`
+}
+
+type EmailVerificationConfig struct {
+	TTL time.Duration `yaml:"ttl" env:"TTL" env-default:"48h"`
+	// URL of the client page verifying the email, the token is appended as "token" query parameter
+	URL string `yaml:"url" env:"URL"`
+	// Restrict is what unverified users may not do: none, read_only or no_board_creation
+	Restrict string `yaml:"restrict" env:"RESTRICT" env-default:"none"`
+	// Since is when email verification was introduced (RFC3339), users registered before it without
+	// a verification state are verified. When empty, such users are unverified, it is required
+	// unless Restrict is none.
+	Since time.Time `yaml:"since" env:"SINCE"`
+}
+
+type CursorConfig struct {
+	// Secret signs pagination cursors and must be shared by gateway instances. When empty, a random
+	// one is generated on startup and cursors are accepted only by the instance until it restarts.
+	Secret string `yaml:"secret" env:"SECRET"`
+}
+
 type Config struct {
-	Debug        bool           `yaml:"debug" env:"DEBUG_MODE" env-default:"false"`
-	Log          logger.Config  `yaml:"log" env-prefix:"LOG_"`
-	Server       ServerConfig   `yaml:"server" env-prefix:"SERVER_"`
-	Redis        RedisConfig    `yaml:"redis" env-prefix:"REDIS_"`
-	Services     ServicesConfig `yaml:"services" env-prefix:"SERVICE_"`
-	Swagger      SwaggerConfig  `yaml:"swagger" env-prefix:"SWAGGER_"`
-	RefreshToken token.Config   `yaml:"refresh_token" env-prefix:"REFRESH_TOKEN_"`
-	JWT          struct {
+	Debug             bool                     `yaml:"debug" env:"DEBUG_MODE" env-default:"false"`
+	Log               logger.Config            `yaml:"log" env-prefix:"LOG_"`
+	Server            ServerConfig             `yaml:"server" env-prefix:"SERVER_"`
+	Timeout           TimeoutConfig            `yaml:"timeout" env-prefix:"TIMEOUT_"`
+	Redis             RedisConfig              `yaml:"redis" env-prefix:"REDIS_"`
+	Services          ServicesConfig           `yaml:"services" env-prefix:"SERVICE_"`
+	Swagger           SwaggerConfig            `yaml:"swagger" env-prefix:"SWAGGER_"`
+	RefreshToken      token.Config             `yaml:"refresh_token" env-prefix:"REFRESH_TOKEN_"`
+	LoginThrottle     throttle.LoginConfig     `yaml:"login_throttle" env-prefix:"LOGIN_THROTTLE_"`
+	RateLimit         throttle.RateLimitConfig `yaml:"rate_limit" env-prefix:"RATE_LIMIT_"`
+	Notify            notify.Config            `yaml:"notify" env-prefix:"NOTIFY_"`
+	PasswordReset     PasswordResetConfig      `yaml:"password_reset" env-prefix:"PASSWORD_RESET_"`
+	EmailVerification EmailVerificationConfig  `yaml:"email_verification" env-prefix:"EMAIL_VERIFICATION_"`
+	BoardDeletion     jobs.DeletionConfig      `yaml:"board_deletion" env-prefix:"BOARD_DELETION_"`
+	Trash             trash.Config             `yaml:"trash" env-prefix:"TRASH_"`
+	Cursor            CursorConfig             `yaml:"cursor" env-prefix:"CURSOR_"`
+	AuthzCache        authzcache.Config        `yaml:"authz_cache" env-prefix:"AUTHZ_CACHE_"`
+	Invitation        invitation.Config        `yaml:"invitation" env-prefix:"INVITATION_"`
+	JWT               struct {
 		Signer   jwt.SignerConfig   `yaml:"signer" env-prefix:"SIGNER_"`
 		Verifier jwt.VerifierConfig `yaml:"verifier" env-prefix:"VERIFIER_"`
 	} `yaml:"jwt" env-prefix:"JWT_"`
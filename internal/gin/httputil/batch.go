@@ -0,0 +1,36 @@
+package httputil
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// BatchItem is the result of an item of a batch request, Index is its position in the request.
+type BatchItem struct {
+	Index  int       `json:"index"`
+	ID     string    `json:"id,omitempty"`
+	Status int       `json:"status"`
+	Error  *APIError `json:"error,omitempty"`
+}
+
+type BatchResponse struct {
+	Data []BatchItem `json:"data"`
+}
+
+// NewBatchItem returns the result of the item, err is converted like errors of a single item request.
+func NewBatchItem(index int, id string, status int, err error) BatchItem {
+	item := BatchItem{Index: index, ID: id, Status: status}
+	if err != nil {
+		item.Error = Errors[http.StatusInternalServerError]
+		if apiErr, ok := ToAPIError(err); ok {
+			item.Error = apiErr
+		}
+		item.Status = item.Error.StatusCode
+	}
+	return item
+}
+
+// MultiStatus writes results of a batch request, every item has its own status.
+func MultiStatus(c *gin.Context, items []BatchItem) {
+	c.JSON(http.StatusMultiStatus, BatchResponse{Data: items})
+}
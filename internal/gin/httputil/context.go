@@ -5,28 +5,85 @@ import (
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/go-funcards/funapi/internal/client/authz_service/v1"
+	"github.com/go-funcards/funapi/internal/client/interceptor"
 	proto "github.com/go-funcards/funapi/proto/authz_service/v1"
 	"github.com/go-funcards/jwt"
-	"google.golang.org/grpc/metadata"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 )
 
 const (
 	User        = "user"
 	UserID      = "user_id"
 	AccessToken = "access_token"
+	RequestID   = "request_id"
 )
 
-type IsGrantedFn func(ctx context.Context, c *gin.Context, owner, ref, act string) error
+// IsGrantedFn checks the action on the object of the owner and ref, or on its child when given.
+type IsGrantedFn func(ctx context.Context, c *gin.Context, owner, ref, act string, child ...v1.Child) error
 
-func IsGranted(client proto.AuthorizationCheckerClient, name string) func(ctx context.Context, c *gin.Context, owner, ref, act string) error {
-	return func(ctx context.Context, c *gin.Context, owner, ref, act string) error {
-		if v1.IsGranted(ctx, client, GetUserID(c), v1.NewObject(name, owner, ref), act) {
-			return nil
+// IsGranted returns ErrForbidden when the action is denied and ErrServiceUnavailable when it could not be checked.
+func IsGranted(client proto.AuthorizationCheckerClient, name string) IsGrantedFn {
+	isGrantedTo := IsGrantedTo(client, name)
+	return func(ctx context.Context, c *gin.Context, owner, ref, act string, child ...v1.Child) error {
+		return isGrantedTo(ctx, GetUserID(c), owner, ref, act, child...)
+	}
+}
+
+// IsGrantedToFn is IsGrantedFn checking the action for the subject instead of the authenticated user.
+type IsGrantedToFn func(ctx context.Context, sub, owner, ref, act string, child ...v1.Child) error
+
+// IsGrantedTo is IsGranted for the subject, e.g. the user who invited the authenticated one.
+func IsGrantedTo(client proto.AuthorizationCheckerClient, name string) IsGrantedToFn {
+	return func(ctx context.Context, sub, owner, ref, act string, child ...v1.Child) error {
+		granted, err := v1.Check(ctx, client, sub, v1.NewObject(name, owner, ref, child...), act)
+		return grantedError(granted, err)
+	}
+}
+
+// Object is the owner, ref and optional child of an object checked by AreGrantedFn.
+type Object struct {
+	Owner string
+	Ref   string
+	Child *v1.Child
+}
+
+// AreGrantedFn checks the action on many objects, errors are in the order of the objects.
+type AreGrantedFn func(ctx context.Context, c *gin.Context, objects []Object, act string) []error
+
+// AreGranted is IsGranted checking many objects at once.
+func AreGranted(checker *v1.BatchChecker, name string) AreGrantedFn {
+	return func(ctx context.Context, c *gin.Context, objects []Object, act string) []error {
+		sub := GetUserID(c)
+		tuples := make([]v1.Tuple, len(objects))
+		for i, o := range objects {
+			obj := v1.NewObject(name, o.Owner, o.Ref)
+			if o.Child != nil {
+				obj = v1.NewObject(name, o.Owner, o.Ref, *o.Child)
+			}
+			tuples[i] = v1.Tuple{Sub: sub, Obj: obj, Act: act}
 		}
+
+		decisions := checker.Check(ctx, tuples)
+		errs := make([]error, len(decisions))
+		for i, d := range decisions {
+			errs[i] = grantedError(d.Granted, d.Err)
+		}
+		return errs
+	}
+}
+
+func grantedError(granted bool, err error) error {
+	switch {
+	case err != nil:
+		return ErrServiceUnavailable
+	case !granted:
 		return ErrForbidden
 	}
+	return nil
 }
 
 func SetUser(c *gin.Context, user jwt.User) {
@@ -53,12 +110,29 @@ func GetAccessToken(c *gin.Context) string {
 	return c.GetString(AccessToken)
 }
 
+func SetRequestID(c *gin.Context, requestID string) {
+	c.Set(RequestID, requestID)
+}
+
+func GetRequestID(c *gin.Context) string {
+	return c.GetString(RequestID)
+}
+
+// GRPCAuthContext attaches the caller metadata, which interceptor.UnaryClientMetadata
+// forwards to downstream services.
 func GRPCAuthContext(ctx context.Context, c *gin.Context) context.Context {
-	return metadata.AppendToOutgoingContext(ctx, "authorization", GetAccessToken(c))
+	return interceptor.WithMetadata(ctx, interceptor.Metadata{
+		AccessToken: GetAccessToken(c),
+		UserID:      GetUserID(c),
+		RequestID:   GetRequestID(c),
+		ClientIP:    c.ClientIP(),
+	})
 }
 
+// GRPCAuth returns the request context with the caller metadata, so that
+// downstream calls are cancelled together with the request.
 func GRPCAuth(c *gin.Context) context.Context {
-	return GRPCAuthContext(context.TODO(), c)
+	return GRPCAuthContext(c.Request.Context(), c)
 }
 
 func CtxWithUserID(c *gin.Context) (context.Context, string) {
@@ -74,6 +148,12 @@ func NoContent(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// TooManyRequests rejects the request, telling the client when to retry.
+func TooManyRequests(c *gin.Context, retryAfter time.Duration) {
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	_ = c.Error(ErrTooManyRequests)
+}
+
 func NoCache(c *gin.Context) {
 	c.Writer.Header().Set("Cache-Control", "no-store")
 	c.Writer.Header().Set("Pragma", "no-cache")
@@ -0,0 +1,176 @@
+package httputil
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// maxWindowPages bounds the pages fetched to locate the last seen item of a cursor.
+const maxWindowPages = 4
+
+var cursorSecret []byte
+
+// SetCursorSecret sets the key signing cursors, it is called once on startup. Gateway instances
+// must share it, otherwise a cursor is accepted only by the instance that issued it.
+func SetCursorSecret(secret []byte) {
+	cursorSecret = secret
+}
+
+// RandomCursorSecret returns a random key for SetCursorSecret.
+func RandomCursorSecret() ([]byte, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// Cursor points after the last item of a page. Services page by offset only, so it holds
+// the offset of the next item and the ID of the last seen one, which is the key the items
+// are ordered by. Scope binds the cursor to the user, path and filters of the request.
+type Cursor struct {
+	Offset uint64 `json:"o"`
+	LastID string `json:"l"`
+	Scope  string `json:"s"`
+}
+
+// PageFetchFn returns the page of items by index and size, and the total number of items.
+type PageFetchFn[T any] func(index uint64, size uint32) ([]T, uint64, error)
+
+// Paginate returns the page of items requested by the cursor, or by the page index when there is no cursor.
+// The response holds the cursor of the next page, if any.
+//
+// When items were inserted or deleted before the cursor, the page starts after the last seen item,
+// which is looked up around the offset, so items are neither skipped nor duplicated. When it moved
+// too far or was deleted, the page starts at the offset.
+func Paginate[T any](c *gin.Context, req PageRequest, fetch PageFetchFn[T], id func(T) string) ([]T, PageResponse, error) {
+	scope := cursorScope(c)
+	size := uint64(req.Size)
+
+	if req.Cursor == "" {
+		items, total, err := fetch(req.Index, req.Size)
+		if err != nil {
+			return nil, PageResponse{}, err
+		}
+		return items, nextPage(req.Index*size, req.Size, items, total, scope, id), nil
+	}
+
+	cursor, err := decodeCursor(req.Cursor)
+	if err != nil || cursor.Scope != scope {
+		return nil, PageResponse{}, newAPIError(http.StatusBadRequest, gin.H{"cursor": "invalid cursor"})
+	}
+
+	// the last seen item is looked up from the page before the one it was on to the page after it
+	var first, last uint64
+	if cursor.Offset > 0 {
+		last = (cursor.Offset-1)/size + 1
+	}
+	if last > 1 {
+		first = last - 2
+	}
+	from, start := first*size, cursor.Offset
+
+	var (
+		window  []T
+		total   uint64
+		located bool
+	)
+	for index := first; index < first+maxWindowPages; index++ {
+		items, t, err := fetch(index, req.Size)
+		if err != nil {
+			return nil, PageResponse{}, err
+		}
+		window, total = append(window, items...), t
+
+		if !located {
+			for i := range window {
+				if id(window[i]) == cursor.LastID {
+					start, located = from+uint64(i)+1, true
+					break
+				}
+			}
+		}
+
+		end := from + uint64(len(window))
+		if uint64(len(items)) < size || end >= total || (end >= start+size && (located || index >= last)) {
+			break
+		}
+	}
+
+	lo := start - from
+	if lo > uint64(len(window)) {
+		lo = uint64(len(window))
+	}
+	hi := lo + size
+	if hi > uint64(len(window)) {
+		hi = uint64(len(window))
+	}
+	items := window[lo:hi]
+
+	return items, nextPage(start, req.Size, items, total, scope, id), nil
+}
+
+func nextPage[T any](offset uint64, size uint32, items []T, total uint64, scope string, id func(T) string) PageResponse {
+	page := PageResponse{
+		Index: offset / uint64(size),
+		Size:  size,
+		Total: total,
+	}
+	if next := offset + uint64(len(items)); len(items) > 0 && next < total {
+		page.NextCursor = encodeCursor(Cursor{
+			Offset: next,
+			LastID: id(items[len(items)-1]),
+			Scope:  scope,
+		})
+	}
+	return page
+}
+
+// cursorScope hashes the user, path and query of the request, except for the paging parameters.
+func cursorScope(c *gin.Context) string {
+	query := url.Values{}
+	for k, v := range c.Request.URL.Query() {
+		if k != "cursor" && k != "page_index" && k != "page_size" {
+			query[k] = v
+		}
+	}
+	sum := sha256.Sum256([]byte(strings.Join([]string{GetUserID(c), c.Request.URL.Path, query.Encode()}, "\n")))
+	return base64.RawURLEncoding.EncodeToString(sum[:12])
+}
+
+func encodeCursor(cursor Cursor) string {
+	data, _ := json.Marshal(cursor)
+	payload := base64.RawURLEncoding.EncodeToString(data)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(signCursor(payload))
+}
+
+func decodeCursor(value string) (Cursor, error) {
+	var cursor Cursor
+
+	payload, sig, ok := strings.Cut(value, ".")
+	if !ok {
+		return cursor, ErrBadRequest
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, signCursor(payload)) {
+		return cursor, ErrBadRequest
+	}
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return cursor, err
+	}
+	return cursor, json.Unmarshal(data, &cursor)
+}
+
+func signCursor(payload string) []byte {
+	mac := hmac.New(sha256.New, cursorSecret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
@@ -1,7 +1,14 @@
 package httputil
 
 import (
+	"errors"
 	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-funcards/validate"
+	"github.com/go-playground/validator/v10"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"net/http"
 )
 
@@ -13,6 +20,8 @@ var (
 	ErrInternalServerError = NewAPIError(http.StatusInternalServerError, "server_error", nil)
 	ErrUnauthorized        = NewAPIError(http.StatusUnauthorized, "unauthorized", nil)
 	ErrForbidden           = NewAPIError(http.StatusForbidden, "forbidden", nil)
+	ErrTooManyRequests     = NewAPIError(http.StatusTooManyRequests, "too_many_requests", nil)
+	ErrServiceUnavailable  = NewAPIError(http.StatusServiceUnavailable, "service_unavailable", nil)
 )
 
 var Errors = map[int]*APIError{
@@ -23,6 +32,17 @@ var Errors = map[int]*APIError{
 	http.StatusInternalServerError: ErrInternalServerError,
 	http.StatusUnauthorized:        ErrUnauthorized,
 	http.StatusForbidden:           ErrForbidden,
+	http.StatusTooManyRequests:     ErrTooManyRequests,
+	http.StatusServiceUnavailable:  ErrServiceUnavailable,
+}
+
+var gRPCCodes = map[codes.Code]int{
+	codes.Internal:         http.StatusInternalServerError,
+	codes.NotFound:         http.StatusNotFound,
+	codes.AlreadyExists:    http.StatusConflict,
+	codes.Unauthenticated:  http.StatusUnauthorized,
+	codes.PermissionDenied: http.StatusForbidden,
+	codes.Unavailable:      http.StatusServiceUnavailable,
 }
 
 type APIError struct {
@@ -49,3 +69,72 @@ func (e *APIError) SetErrors(errors any) *APIError {
 func (e *APIError) Error() string {
 	return fmt.Sprintf("[%d][%s] %s %v", e.StatusCode, e.ErrorCode, e.Message, e.Errors)
 }
+
+// ToAPIError converts err or an error it wraps to the APIError returned to the client.
+func ToAPIError(err error) (*APIError, bool) {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if apiErr, ok := toAPIError(err); ok {
+			return apiErr, true
+		}
+	}
+	return nil, false
+}
+
+func toAPIError(err error) (*APIError, bool) {
+	switch tmp := err.(type) {
+	case validate.SliceValidateError:
+		if len(tmp) > 0 {
+			if _, ok := tmp[0].(validator.ValidationErrors); ok {
+				return toAPIError(tmp[0])
+			}
+		}
+	case validator.ValidationErrors:
+		sc := http.StatusUnprocessableEntity
+		return newAPIError(sc, validationErrors(tmp)), true
+	case *APIError:
+		return tmp, true
+	}
+
+	if s, ok := status.FromError(err); ok {
+		sc := http.StatusInternalServerError
+		if found, ok := gRPCCodes[s.Code()]; ok {
+			sc = found
+		} else if s.Code() == codes.InvalidArgument {
+			for _, e := range s.Details() {
+				if br, ok := e.(*errdetails.BadRequest); ok {
+					data := gin.H{}
+					for _, fv := range br.FieldViolations {
+						if f, ok := data[fv.GetField()]; ok {
+							data[fv.GetField()] = append(f.([]string), fv.GetDescription())
+						} else {
+							data[fv.GetField()] = []string{fv.GetDescription()}
+						}
+					}
+					return newAPIError(http.StatusBadRequest, data), true
+				}
+			}
+		}
+		return Errors[sc], true
+	}
+	return nil, false
+}
+
+// newAPIError copies the predefined error of the status, so that its errors are not shared between requests.
+func newAPIError(sc int, errors any) *APIError {
+	return NewAPIError(sc, Errors[sc].ErrorCode, errors)
+}
+
+func validationErrors(vErrors validator.ValidationErrors) gin.H {
+	data := gin.H{}
+	for _, ve := range vErrors {
+		if i, ok := data[ve.Field()]; ok {
+			field := i.(gin.H)
+			field[ve.Tag()] = ve.Error()
+		} else {
+			data[ve.Field()] = gin.H{
+				ve.Tag(): ve.Error(),
+			}
+		}
+	}
+	return data
+}
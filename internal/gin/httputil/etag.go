@@ -0,0 +1,43 @@
+package httputil
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+)
+
+// JSONWithETag writes obj with an ETag of its content. When the request has a matching
+// If-None-Match header, only 304 Not Modified is written.
+func JSONWithETag(c *gin.Context, code int, obj any) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	sum := sha256.Sum256(data)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "private, no-cache")
+
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(code, gin.MIMEJSON+"; charset=utf-8", data)
+}
+
+func etagMatches(header, etag string) bool {
+	for _, value := range strings.Split(header, ",") {
+		value = strings.TrimPrefix(strings.TrimSpace(value), "W/")
+		if value == "*" || value == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -3,12 +3,16 @@ package httputil
 type PageRequest struct {
 	Index uint64 `json:"-" form:"page_index"`
 	Size  uint32 `json:"-" form:"page_size" validate:"min=1,max=1000"`
+	// Cursor is the next_cursor of the previous page, it takes precedence over Index
+	Cursor string `json:"-" form:"cursor" validate:"omitempty,max=1000"`
 }
 
 type PageResponse struct {
 	Index uint64 `json:"page_index"`
 	Size  uint32 `json:"page_size"`
 	Total uint64 `json:"total"`
+	// NextCursor is empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 func (p PageRequest) ToPageResponse(total uint64) PageResponse {
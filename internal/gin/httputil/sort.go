@@ -0,0 +1,128 @@
+package httputil
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-funcards/slice"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Sort is a comma separated list of fields to sort by, e.g. "-created_at,name".
+// A field prefixed with "-" is sorted in descending order.
+//
+// Backend services do not support sorting yet, so the gateway sorts the page it returns.
+// It does so only when the page holds the whole result, as sorting a page of a larger
+// result would be inconsistent across pages, see CheckSortable.
+type Sort string
+
+type SortField struct {
+	Name string
+	Desc bool
+}
+
+// Fields returns the parsed fields.
+func (s Sort) Fields() []SortField {
+	if s == "" {
+		return nil
+	}
+	parts := strings.Split(string(s), ",")
+	fields := make([]SortField, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		fields = append(fields, SortField{
+			Name: strings.TrimPrefix(part, "-"),
+			Desc: strings.HasPrefix(part, "-"),
+		})
+	}
+	return fields
+}
+
+// CheckFirstPage returns an error when the sort is set and the page is not the first one,
+// so that it is rejected before the page is fetched. The cursor holds the offset of the
+// unsorted result, a sorted page is valid only as the first one.
+func (s Sort) CheckFirstPage(page PageRequest) error {
+	if s == "" || (page.Cursor == "" && page.Index == 0) {
+		return nil
+	}
+	return errNotSortable()
+}
+
+// CheckSortable returns an error when the sort is set and the page does not hold the whole result.
+func (s Sort) CheckSortable(page PageRequest, total uint64) error {
+	if err := s.CheckFirstPage(page); err != nil || s == "" || total <= uint64(page.Size) {
+		return err
+	}
+	return errNotSortable()
+}
+
+func errNotSortable() error {
+	return newAPIError(http.StatusBadRequest, gin.H{
+		"sort": "sorting is supported only when the result fits on the first page, increase page_size",
+	})
+}
+
+// SortCompareFn compares the field of two items, returning a negative number, zero or a positive number.
+type SortCompareFn[T any] func(a, b T, field string) int
+
+// SortSlice stably sorts the items by the fields of the sort.
+func SortSlice[T any](items []T, s Sort, compare SortCompareFn[T]) {
+	fields := s.Fields()
+	if len(fields) == 0 {
+		return
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		for _, f := range fields {
+			r := compare(items[i], items[j], f.Name)
+			if f.Desc {
+				r = -r
+			}
+			if r != 0 {
+				return r < 0
+			}
+		}
+		return false
+	})
+}
+
+func CompareStrings(a, b string) int {
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+func CompareInts[T ~int32 | ~int64 | ~uint64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func CompareTimes(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+	return 0
+}
+
+// RegisterSortValidation registers the "sort" validation, its param is a space separated
+// whitelist of fields, e.g. `validate:"omitempty,sort=created_at name"`. Fields must not repeat.
+func RegisterSortValidation(v *validator.Validate) error {
+	return v.RegisterValidation("sort", func(fl validator.FieldLevel) bool {
+		allowed := strings.Fields(fl.Param())
+		seen := make(map[string]bool)
+		for _, f := range Sort(fl.Field().String()).Fields() {
+			if f.Name == "" || seen[f.Name] || !slice.Contains(allowed, f.Name) {
+				return false
+			}
+			seen[f.Name] = true
+		}
+		return true
+	})
+}
@@ -1,16 +1,27 @@
 package middleware
 
 import (
+	"context"
 	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/go-funcards/funapi/internal/gin/httputil"
 	"github.com/go-funcards/jwt"
 	"net/http"
 	"strings"
+	"time"
 )
 
-// ErrInvalidAuthHeader indicates authorization header is invalid, could for example have the wrong Realm name
-var ErrInvalidAuthHeader = errors.New("authorization header is invalid")
+var (
+	// ErrInvalidAuthHeader indicates authorization header is invalid, could for example have the wrong Realm name
+	ErrInvalidAuthHeader = errors.New("authorization header is invalid")
+	// ErrRevokedToken indicates access token was revoked by logout
+	ErrRevokedToken = errors.New("access token is revoked")
+)
+
+// DenyList reports access tokens revoked before their expiration.
+type DenyList interface {
+	IsDenied(ctx context.Context, accessToken, userID string, issuedAt time.Time) (bool, error)
+}
 
 var (
 	messages = map[int]*httputil.APIError{
@@ -27,7 +38,7 @@ var (
 	}
 )
 
-func Authorize(verifier jwt.Verifier, authScheme string) gin.HandlerFunc {
+func Authorize(verifier jwt.Verifier, denyList DenyList, authScheme string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := strings.Split(c.Request.Header.Get("Authorization"), " ")
 		if len(authHeader) != 2 || strings.ToLower(authHeader[0]) != strings.ToLower(authScheme) {
@@ -36,13 +47,32 @@ func Authorize(verifier jwt.Verifier, authScheme string) gin.HandlerFunc {
 			return
 		}
 
-		user, err := verifier.ExtractUser(authHeader[1])
+		_, claims, err := verifier.Parse(authHeader[1])
 		if err != nil {
 			_ = c.Error(err)
 			c.AbortWithStatusJSON(http.StatusUnauthorized, c.Error(messages[http.StatusUnauthorized]).Err)
 			return
 		}
 
+		user := claims.User()
+
+		var issuedAt time.Time
+		if claims.IssuedAt != nil {
+			issuedAt = claims.IssuedAt.Time
+		}
+
+		denied, err := denyList.IsDenied(c.Request.Context(), authHeader[1], user.UserID, issuedAt)
+		if err != nil {
+			_ = c.Error(err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, httputil.ErrInternalServerError)
+			return
+		}
+		if denied {
+			_ = c.Error(ErrRevokedToken)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, c.Error(messages[http.StatusUnauthorized]).Err)
+			return
+		}
+
 		httputil.SetAccessToken(c, authHeader[1])
 		httputil.SetUser(c, user)
 
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-funcards/funapi/internal/gin/httputil"
+	"github.com/go-funcards/funapi/internal/throttle"
+	"math"
+	"net/http"
+	"strconv"
+)
+
+type RateLimitKeyFn func(c *gin.Context) string
+
+// ByUserID keys quotas by the authorized user, falling back to the client IP.
+func ByUserID(c *gin.Context) string {
+	if userID := httputil.GetUserID(c); len(userID) > 0 {
+		return "user:" + userID
+	}
+	return ByClientIP(c)
+}
+
+func ByClientIP(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
+}
+
+// RateLimit enforces the quota of the route group and reports it with RateLimit-* headers.
+func RateLimit(limiter *throttle.Limiter, group string, key RateLimitKeyFn) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		result := limiter.Allow(c.Request.Context(), group, key(c))
+		if result.Limit > 0 {
+			c.Header("RateLimit-Limit", strconv.FormatInt(result.Limit, 10))
+			c.Header("RateLimit-Remaining", strconv.FormatInt(result.Remaining, 10))
+			c.Header("RateLimit-Reset", strconv.Itoa(int(math.Ceil(result.Reset.Seconds()))))
+		}
+
+		if !result.Allowed {
+			httputil.TooManyRequests(c, result.Reset)
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, httputil.ErrTooManyRequests)
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-funcards/funapi/internal/gin/httputil"
+	"github.com/google/uuid"
+)
+
+const HeaderRequestID = "X-Request-ID"
+
+// RequestID keeps the request ID sent by the client, or generates a new one,
+// and echoes it in the response.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(HeaderRequestID)
+		if _, err := uuid.Parse(requestID); err != nil {
+			requestID = uuid.NewString()
+		}
+
+		httputil.SetRequestID(c, requestID)
+		c.Header(HeaderRequestID, requestID)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+// Timeout bounds the request context with a deadline, so that a cancelled or timed out
+// request stops every downstream call derived from it. Routes are looked up by
+// "METHOD /full/path", falling back to the default deadline.
+func Timeout(timeout time.Duration, routes map[string]time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		d := timeout
+		if found, ok := routes[c.Request.Method+" "+c.FullPath()]; ok {
+			d = found
+		}
+		if d <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), d)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
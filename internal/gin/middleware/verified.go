@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-funcards/funapi/internal/gin/httputil"
+	"net/http"
+	"strings"
+)
+
+const (
+	RestrictNone          = "none"
+	RestrictReadOnly      = "read_only"
+	RestrictBoardCreation = "no_board_creation"
+)
+
+var ErrEmailNotVerified = &httputil.APIError{
+	StatusCode: http.StatusForbidden,
+	ErrorCode:  "email_not_verified",
+	Message:    "Email address is not verified",
+}
+
+type VerifiedChecker interface {
+	IsVerified(ctx context.Context, userID string) (bool, error)
+}
+
+// RequireVerified restricts what users with an unverified email may do:
+// RestrictReadOnly allows only safe methods, RestrictBoardCreation forbids creating boards.
+// It returns an error for an unknown restriction.
+func RequireVerified(checker VerifiedChecker, restrict string) (gin.HandlerFunc, error) {
+	var restricted func(c *gin.Context) bool
+	switch restrict {
+	case RestrictReadOnly:
+		restricted = func(c *gin.Context) bool {
+			switch c.Request.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				return false
+			}
+			return true
+		}
+	case RestrictBoardCreation:
+		restricted = func(c *gin.Context) bool {
+			return c.Request.Method == http.MethodPost && strings.HasSuffix(c.FullPath(), "/boards")
+		}
+	case RestrictNone:
+		return func(c *gin.Context) {
+			c.Next()
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown email verification restriction %q", restrict)
+	}
+
+	return func(c *gin.Context) {
+		if !restricted(c) {
+			c.Next()
+			return
+		}
+
+		verified, err := checker.IsVerified(c.Request.Context(), httputil.GetUserID(c))
+		if err != nil {
+			_ = c.Error(err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, httputil.ErrInternalServerError)
+			return
+		}
+		if !verified {
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrEmailNotVerified)
+			return
+		}
+
+		c.Next()
+	}, nil
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	v1Authz "github.com/go-funcards/funapi/internal/client/authz_service/v1"
+	"github.com/go-funcards/funapi/internal/gin/httputil"
+	"github.com/go-funcards/slice"
+	"github.com/go-funcards/validate"
+	"github.com/google/uuid"
+	"net/http"
+	"sync"
+)
+
+// batchConcurrency bounds concurrent downstream calls made for items of a batch request.
+const batchConcurrency = 16
+
+// ForEach calls fn for every index in [0, n) with bounded concurrency.
+func ForEach(n int, fn func(i int)) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, batchConcurrency)
+
+	for i := 0; i < n; i++ {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// CreateMany validates every item, checks CREATE once per distinct board and creates
+// the valid items of granted boards. Results are in the order of items.
+func CreateMany[T any](ctx context.Context, c *gin.Context, h *BaseBoard, items []T, boardID func(T) string, create func(ctx context.Context, item T, id string) error) []httputil.BatchItem {
+	results := make([]httputil.BatchItem, len(items))
+	valid := make([]int, 0, len(items))
+	for i, item := range items {
+		if err := validate.Default.ValidateStruct(item); err != nil {
+			results[i] = httputil.NewBatchItem(i, "", 0, err)
+			continue
+		}
+		valid = append(valid, i)
+	}
+
+	granted := h.GrantedBoards(ctx, c, slice.Map(valid, func(i int) string {
+		return boardID(items[i])
+	}), "CREATE")
+
+	ForEach(len(valid), func(j int) {
+		i := valid[j]
+		if err := granted[boardID(items[i])]; err != nil {
+			results[i] = httputil.NewBatchItem(i, "", 0, err)
+			return
+		}
+
+		id := uuid.NewString()
+		if err := create(ctx, items[i], id); err != nil {
+			results[i] = httputil.NewBatchItem(i, "", 0, err)
+			return
+		}
+		results[i] = httputil.NewBatchItem(i, id, http.StatusCreated, nil)
+	})
+
+	return results
+}
+
+// DeleteMany checks DELETE on each found item and deletes the granted items. The item is checked
+// as a child of its board when child is not nil, otherwise DELETE is checked once per distinct board.
+// Results are in the order of ids, ids without a found item are not found.
+func DeleteMany[T any](ctx context.Context, c *gin.Context, h *BaseBoard, ids []string, found []T, id, boardID func(T) string, child func(T) v1Authz.Child, del func(ctx context.Context, item T) error) []httputil.BatchItem {
+	items := make(map[string]T, len(found))
+	granted := make(map[string]error, len(found))
+	if child == nil {
+		boards := h.GrantedBoards(ctx, c, slice.Map(found, boardID), "DELETE")
+		for _, item := range found {
+			items[id(item)] = item
+			granted[id(item)] = boards[boardID(item)]
+		}
+	} else {
+		errs := h.GrantedChildren(ctx, c, slice.Map(found, boardID), slice.Map(found, child), "DELETE")
+		for i, item := range found {
+			items[id(item)] = item
+			granted[id(item)] = errs[i]
+		}
+	}
+
+	results := make([]httputil.BatchItem, len(ids))
+	ForEach(len(ids), func(i int) {
+		item, ok := items[ids[i]]
+		if !ok {
+			results[i] = httputil.NewBatchItem(i, ids[i], 0, httputil.ErrNotFound)
+			return
+		}
+		if err := granted[ids[i]]; err != nil {
+			results[i] = httputil.NewBatchItem(i, ids[i], 0, err)
+			return
+		}
+		results[i] = httputil.NewBatchItem(i, ids[i], http.StatusNoContent, del(ctx, item))
+	})
+
+	return results
+}
+
+// CreatedIDs returns IDs of the items created by a batch request.
+func CreatedIDs(results []httputil.BatchItem) []string {
+	ids := make([]string, 0, len(results))
+	for _, item := range results {
+		if item.Status == http.StatusCreated {
+			ids = append(ids, item.ID)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"context"
+	"sync"
+)
+
+// Concurrently calls the functions concurrently and returns the first error. The context
+// passed to them is cancelled on the first error, so that the others stop early.
+func Concurrently(ctx context.Context, fns ...func(ctx context.Context) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg    sync.WaitGroup
+		once  sync.Once
+		first error
+	)
+	for _, fn := range fns {
+		wg.Add(1)
+		go func(fn func(ctx context.Context) error) {
+			defer wg.Done()
+			if err := fn(ctx); err != nil {
+				once.Do(func() {
+					first = err
+					cancel()
+				})
+			}
+		}(fn)
+	}
+	wg.Wait()
+
+	return first
+}
@@ -2,10 +2,13 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"github.com/gin-gonic/gin"
+	v1Authz "github.com/go-funcards/funapi/internal/client/authz_service/v1"
 	"github.com/go-funcards/funapi/internal/gin/httputil"
 	"github.com/go-funcards/funapi/internal/handlers/v1/clientutil"
 	"github.com/go-funcards/funapi/proto/board_service/v1"
+	"github.com/go-funcards/slice"
 )
 
 type Handler interface {
@@ -15,23 +18,197 @@ type Handler interface {
 type BaseBoard struct {
 	BoardService v1.BoardClient
 	IsGrantedFn  httputil.IsGrantedFn
+	// AreGrantedFn is optional, GrantedBoards checks boards one by one without it
+	AreGrantedFn httputil.AreGrantedFn
 }
 
 func (h *BaseBoard) GetBoard(ctx context.Context, boardID string) (*v1.BoardsResponse_Board, error) {
 	return clientutil.GetBoard(ctx, h.BoardService, boardID)
 }
 
-func (h *BaseBoard) IsGranted(ctx context.Context, c *gin.Context, boardID, act string) bool {
-	board, err := h.GetBoard(ctx, boardID)
+// IsGranted checks the action on the board, or on its child when given, e.g. a card of the board.
+func (h *BaseBoard) IsGranted(ctx context.Context, c *gin.Context, boardID, act string, child ...v1Authz.Child) bool {
+	_, ok := h.GrantedBoard(ctx, c, boardID, act, child...)
+	return ok
+}
+
+// GrantedBoard returns the board if the action on it, or on its child when given, is granted.
+func (h *BaseBoard) GrantedBoard(ctx context.Context, c *gin.Context, boardID, act string, child ...v1Authz.Child) (*v1.BoardsResponse_Board, bool) {
+	board, err := h.Granted(ctx, c, boardID, act, child...)
 	if err != nil {
 		_ = c.Error(err)
-		return false
+		return nil, false
 	}
+	return board, true
+}
 
-	if err = h.IsGrantedFn(ctx, c, board.GetOwnerId(), board.GetBoardId(), act); err != nil {
-		_ = c.Error(err)
-		return false
+// Granted is GrantedBoard returning the error instead of adding it to the context,
+// for requests reporting errors per item.
+func (h *BaseBoard) Granted(ctx context.Context, c *gin.Context, boardID, act string, child ...v1Authz.Child) (*v1.BoardsResponse_Board, error) {
+	board, err := h.GetBoard(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.IsGrantedFn(ctx, c, board.GetOwnerId(), board.GetBoardId(), act, child...); err != nil {
+		return nil, err
+	}
+
+	return board, nil
+}
+
+// GrantedBoards checks the action once per distinct board, the result maps IDs of boards to errors.
+// Boards are checked at once when AreGrantedFn is set.
+func (h *BaseBoard) GrantedBoards(ctx context.Context, c *gin.Context, boardIDs []string, act string) map[string]error {
+	granted := make(map[string]error, len(boardIDs))
+	ids := make([]string, 0, len(boardIDs))
+	for _, id := range boardIDs {
+		if _, ok := granted[id]; !ok {
+			granted[id] = httputil.ErrNotFound
+			ids = append(ids, id)
+		}
 	}
 
+	if h.AreGrantedFn == nil {
+		for _, id := range ids {
+			_, granted[id] = h.Granted(ctx, c, id, act)
+		}
+		return granted
+	}
+
+	boards, err := h.getBoards(ctx, ids)
+	if err != nil {
+		for _, id := range ids {
+			granted[id] = err
+		}
+		return granted
+	}
+
+	found := make([]*v1.BoardsResponse_Board, 0, len(boards))
+	for _, b := range boards {
+		found = append(found, b)
+	}
+	errs := h.AreGrantedFn(ctx, c, slice.Map(found, func(b *v1.BoardsResponse_Board) httputil.Object {
+		return httputil.Object{Owner: b.GetOwnerId(), Ref: b.GetBoardId()}
+	}), act)
+	for i, b := range found {
+		granted[b.GetBoardId()] = errs[i]
+	}
+	return granted
+}
+
+// AreGranted checks the action on every board, adding the error of the first denied board to the context.
+func (h *BaseBoard) AreGranted(ctx context.Context, c *gin.Context, boardIDs []string, act string) bool {
+	granted := h.GrantedBoards(ctx, c, boardIDs, act)
+	for _, id := range boardIDs {
+		if err := granted[id]; err != nil {
+			_ = c.Error(err)
+			return false
+		}
+	}
 	return true
 }
+
+// GrantedChildren checks the action on every child, children[i] belongs to boardIDs[i].
+// Errors are in the order of children.
+func (h *BaseBoard) GrantedChildren(ctx context.Context, c *gin.Context, boardIDs []string, children []v1Authz.Child, act string) []error {
+	errs := make([]error, len(children))
+
+	if h.AreGrantedFn == nil {
+		for i := range children {
+			_, errs[i] = h.Granted(ctx, c, boardIDs[i], act, children[i])
+		}
+		return errs
+	}
+
+	boards, err := h.getBoards(ctx, boardIDs)
+	if err != nil {
+		for i := range errs {
+			errs[i] = err
+		}
+		return errs
+	}
+
+	var (
+		objects []httputil.Object
+		index   []int
+	)
+	for i := range children {
+		board, ok := boards[boardIDs[i]]
+		if !ok {
+			errs[i] = httputil.ErrNotFound
+			continue
+		}
+		objects = append(objects, httputil.Object{Owner: board.GetOwnerId(), Ref: board.GetBoardId(), Child: &children[i]})
+		index = append(index, i)
+	}
+	for j, err := range h.AreGrantedFn(ctx, c, objects, act) {
+		errs[index[j]] = err
+	}
+	return errs
+}
+
+// AreChildrenGranted is GrantedChildren adding the first error to the context.
+func (h *BaseBoard) AreChildrenGranted(ctx context.Context, c *gin.Context, boardIDs []string, children []v1Authz.Child, act string) bool {
+	for _, err := range h.GrantedChildren(ctx, c, boardIDs, children, act) {
+		if err != nil {
+			_ = c.Error(err)
+			return false
+		}
+	}
+	return true
+}
+
+// GrantedItems returns the items the action is granted on as children of their boards, or on their boards
+// when child is nil. Denied items are omitted. It adds the error to the context when the action could not be checked.
+func GrantedItems[T any](ctx context.Context, c *gin.Context, h *BaseBoard, items []T, boardID func(T) string, child func(T) v1Authz.Child, act string) ([]T, bool) {
+	var errs []error
+	if child == nil {
+		boards := h.GrantedBoards(ctx, c, slice.Map(items, boardID), act)
+		errs = slice.Map(items, func(item T) error {
+			return boards[boardID(item)]
+		})
+	} else {
+		errs = h.GrantedChildren(ctx, c, slice.Map(items, boardID), slice.Map(items, child), act)
+	}
+
+	granted := make([]T, 0, len(items))
+	for i, err := range errs {
+		switch {
+		case err == nil:
+			granted = append(granted, items[i])
+		case errors.Is(err, httputil.ErrForbidden), errors.Is(err, httputil.ErrNotFound):
+		default:
+			_ = c.Error(err)
+			return nil, false
+		}
+	}
+	return granted, true
+}
+
+// getBoards returns the found boards by ID.
+func (h *BaseBoard) getBoards(ctx context.Context, boardIDs []string) (map[string]*v1.BoardsResponse_Board, error) {
+	ids := make([]string, 0, len(boardIDs))
+	for _, id := range boardIDs {
+		if !slice.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+
+	boards := make(map[string]*v1.BoardsResponse_Board, len(ids))
+	if len(ids) == 0 {
+		return boards, nil
+	}
+
+	response, err := h.BoardService.GetBoards(ctx, &v1.BoardsRequest{
+		PageSize: uint32(len(ids)),
+		BoardIds: ids,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range response.GetBoards() {
+		boards[b.GetBoardId()] = b
+	}
+	return boards, nil
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import "math"
+
+// PositionGap is the distance between positions of neighbours after rebalancing,
+// it leaves room to insert between them without moving anything else.
+const PositionGap = 1 << 10
+
+// PositionBetween returns a position between prev and next, nil means there is no neighbour
+// on that side. It returns false when there is no free position, then the list must be rebalanced.
+// Zero is never returned, services treat it as an unset position.
+func PositionBetween(prev, next *int32) (int32, bool) {
+	var lo, hi int64
+	switch {
+	case prev == nil && next == nil:
+		return PositionGap, true
+	case prev == nil:
+		hi = int64(*next)
+		lo = hi - 2*PositionGap
+	case next == nil:
+		lo = int64(*prev)
+		hi = lo + 2*PositionGap
+	default:
+		lo, hi = int64(*prev), int64(*next)
+	}
+
+	pos := lo + (hi-lo)/2
+	if hi-lo < 2 || pos == 0 || pos < math.MinInt32 || pos > math.MaxInt32 {
+		return 0, false
+	}
+	return int32(pos), true
+}
+
+// Positions returns n positions spaced by PositionGap.
+func Positions(n int) []int32 {
+	positions := make([]int32, n)
+	for i := range positions {
+		positions[i] = int32((i + 1) * PositionGap)
+	}
+	return positions
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"time"
+)
+
+const compensateTimeout = 10 * time.Second
+
+type SagaFn func(ctx context.Context) error
+
+type sagaStep struct {
+	name       string
+	compensate SagaFn
+}
+
+// Saga runs the steps of a multi-service operation in order. When a step fails,
+// the completed steps are compensated in reverse order, so that no service is
+// left with a half-applied change.
+type Saga struct {
+	log   *zap.Logger
+	steps []sagaStep
+}
+
+func NewSaga(log *zap.Logger) *Saga {
+	return &Saga{log: log}
+}
+
+// Do runs the action, compensation may be nil for the last step or steps without side effects.
+// On failure it returns the error of the action after compensating the completed steps.
+func (s *Saga) Do(ctx context.Context, name string, action, compensate SagaFn) error {
+	s.log.Debug("saga::do", zap.String("step", name))
+	if err := action(ctx); err != nil {
+		s.compensate(ctx, name, err)
+		return err
+	}
+	if compensate != nil {
+		s.steps = append(s.steps, sagaStep{name: name, compensate: compensate})
+	}
+	return nil
+}
+
+// compensate runs even when the request context is already cancelled or timed out,
+// which is a common reason of the failure.
+func (s *Saga) compensate(ctx context.Context, failed string, cause error) {
+	ctx, cancel := context.WithTimeout(detached{ctx}, compensateTimeout)
+	defer cancel()
+
+	for i := len(s.steps) - 1; i >= 0; i-- {
+		step := s.steps[i]
+		s.log.Debug("saga::compensate", zap.String("step", step.name), zap.String("failed", failed))
+		if err := step.compensate(ctx); err != nil {
+			s.log.Error("saga::compensate failed",
+				zap.String("step", step.name),
+				zap.String("failed", failed),
+				zap.NamedError("cause", cause),
+				zap.Error(err),
+			)
+		}
+	}
+	s.steps = nil
+}
+
+// detached keeps values of the parent context, e.g. the caller metadata, but not its cancellation.
+type detached struct {
+	context.Context
+}
+
+func (detached) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detached) Done() <-chan struct{} {
+	return nil
+}
+
+func (detached) Err() error {
+	return nil
+}
@@ -6,19 +6,39 @@ import (
 	"github.com/go-funcards/funapi/internal/gin/binding"
 	"github.com/go-funcards/funapi/internal/gin/httputil"
 	"github.com/go-funcards/funapi/internal/handlers"
+	"github.com/go-funcards/funapi/internal/handlers/v1/cards"
+	"github.com/go-funcards/funapi/internal/handlers/v1/categories"
+	"github.com/go-funcards/funapi/internal/handlers/v1/clientutil"
+	"github.com/go-funcards/funapi/internal/jobs"
+	"github.com/go-funcards/funapi/internal/trash"
 	v1Authz "github.com/go-funcards/funapi/proto/authz_service/v1"
+	v1Board "github.com/go-funcards/funapi/proto/board_service/v1"
+	v1Card "github.com/go-funcards/funapi/proto/card_service/v1"
+	v1Category "github.com/go-funcards/funapi/proto/category_service/v1"
+	v1Tag "github.com/go-funcards/funapi/proto/tag_service/v1"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"net/http"
+	"strings"
+	"time"
 )
 
 var _ handlers.Handler = (*Handler)(nil)
 
 type Handler struct {
 	*handlers.BaseBoard
-	MemberHandler  handlers.Handler
-	SubjectService v1Authz.SubjectClient
-	Log            *zap.Logger
+	MemberHandler        handlers.Handler
+	CategoryOrderHandler handlers.Handler
+	// Categories and Cards check categories and cards of the full board
+	Categories      *handlers.BaseBoard
+	Cards           *handlers.BaseBoard
+	SubjectService  v1Authz.SubjectClient
+	CategoryService v1Category.CategoryClient
+	CardService     v1Card.CardClient
+	TagService      v1Tag.TagClient
+	Trash           *trash.Store
+	Deletion        *jobs.BoardDeletion
+	Log             *zap.Logger
 }
 
 func (h *Handler) Register(rg *gin.RouterGroup) {
@@ -30,10 +50,13 @@ func (h *Handler) Register(rg *gin.RouterGroup) {
 		b := g.Group("/:board_id")
 		{
 			b.GET("", h.read)
+			b.GET("/full", h.full)
 			b.PATCH("", h.update)
 			b.DELETE("", h.delete)
+			b.GET("/deletion", h.deletion)
 
 			h.MemberHandler.Register(b)
+			h.CategoryOrderHandler.Register(b)
 		}
 	}
 }
@@ -45,12 +68,14 @@ func (h *Handler) Register(rg *gin.RouterGroup) {
 // @Produce json
 // @Param page_index query int false "Page Index" minimum(0)
 // @Param page_size query int false "Page Size" minimum(1) maximum(1000)
+// @Param cursor query string false "Cursor of the next page, takes precedence over page_index. It holds the offset and the ID of the last returned item, not a sort key, so it cannot be combined with sort"
+// @Param sort query string false "Comma separated fields, prefixed with - for descending order: created_at, name, type. Supported only when the result fits on the first page"
 // @Success 200 {object} boards.PageResponse
 // @Failure 400,401,403,500 {object} httputil.APIError
 // @Router /boards [get]
 // @Security BearerAuth
 func (h *Handler) list(c *gin.Context) {
-	ctx := context.TODO()
+	ctx := httputil.GRPCAuth(c)
 
 	h.Log.Debug("board handler::list bind")
 	req := PageReq()
@@ -58,14 +83,27 @@ func (h *Handler) list(c *gin.Context) {
 		return
 	}
 
-	h.Log.Debug("board handler::list call gRPC /BoardClient/GetBoards")
-	response, err := h.BoardService.GetBoards(ctx, req.toRead())
+	if err := req.Sort.CheckFirstPage(req.PageRequest); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	items, page, err := httputil.Paginate(c, req.PageRequest, func(index uint64, size uint32) ([]*v1Board.BoardsResponse_Board, uint64, error) {
+		h.Log.Debug("board handler::list call gRPC /BoardClient/GetBoards")
+		response, err := h.BoardService.GetBoards(ctx, req.toRead(index, size))
+		return response.GetBoards(), response.GetTotal(), err
+	}, (*v1Board.BoardsResponse_Board).GetBoardId)
 	if err != nil {
 		_ = c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, PageResp(response, req))
+	if err = req.Sort.CheckSortable(req.PageRequest, page.Total); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, PageResp(items, page, req))
 }
 
 // @Summary Create Board
@@ -79,7 +117,7 @@ func (h *Handler) list(c *gin.Context) {
 // @Router /boards [post]
 // @Security BearerAuth
 func (h *Handler) create(c *gin.Context) {
-	ctx := context.TODO()
+	ctx := httputil.GRPCAuth(c)
 
 	if err := h.IsGrantedFn(ctx, c, "", "", "CREATE"); err != nil {
 		_ = c.Error(err)
@@ -114,7 +152,7 @@ func (h *Handler) create(c *gin.Context) {
 // @Router /boards/{board_id} [get]
 // @Security BearerAuth
 func (h *Handler) read(c *gin.Context) {
-	ctx := context.TODO()
+	ctx := httputil.GRPCAuth(c)
 
 	h.Log.Debug("board handler::read bind")
 	var dto ReadBoardDTO
@@ -137,6 +175,68 @@ func (h *Handler) read(c *gin.Context) {
 	c.JSON(http.StatusOK, CreateBoard(board))
 }
 
+// @Summary Full Board
+// @Tags Boards
+// @Description Return the board with all its categories, their cards ordered by position, and tags.
+// @Description Categories and cards the user may not read are omitted.
+// @Description Responds 304 when If-None-Match matches the ETag of the document.
+// @ModuleID fullBoard
+// @Produce json
+// @Param board_id path string true "Board ID" format(uuid)
+// @Param If-None-Match header string false "ETag of a previously returned document"
+// @Success 200 {object} boards.FullBoard
+// @Success 304
+// @Header 200 {string} ETag "Hash of the document"
+// @Failure 400,401,403,404,500 {object} httputil.APIError
+// @Router /boards/{board_id}/full [get]
+// @Security BearerAuth
+func (h *Handler) full(c *gin.Context) {
+	ctx := httputil.GRPCAuth(c)
+
+	h.Log.Debug("board handler::full bind")
+	var dto ReadBoardDTO
+	if !binding.BindUriAndValidate(c, &dto) {
+		return
+	}
+
+	board, ok := h.GrantedBoard(ctx, c, dto.BoardID, "READ")
+	if !ok {
+		return
+	}
+
+	var (
+		categoryList []*v1Category.CategoriesResponse_Category
+		cardList     []*v1Card.CardsResponse_Card
+		tagList      []*v1Tag.TagsResponse_Tag
+	)
+	err := handlers.Concurrently(ctx, func(ctx context.Context) (err error) {
+		h.Log.Debug("board handler::full call gRPC /CategoryClient/GetCategories")
+		categoryList, err = clientutil.GetAllCategories(ctx, h.CategoryService, &v1Category.CategoriesRequest{BoardIds: []string{dto.BoardID}})
+		return
+	}, func(ctx context.Context) (err error) {
+		h.Log.Debug("board handler::full call gRPC /CardClient/GetCards")
+		cardList, err = clientutil.GetAllCards(ctx, h.CardService, &v1Card.CardsRequest{BoardIds: []string{dto.BoardID}})
+		return
+	}, func(ctx context.Context) (err error) {
+		h.Log.Debug("board handler::full call gRPC /TagClient/GetTags")
+		tagList, err = clientutil.GetAllTags(ctx, h.TagService, &v1Tag.TagsRequest{BoardIds: []string{dto.BoardID}})
+		return
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if categoryList, ok = handlers.GrantedItems(ctx, c, h.Categories, categoryList, (*v1Category.CategoriesResponse_Category).GetBoardId, categories.ToChild, "READ"); !ok {
+		return
+	}
+	if cardList, ok = handlers.GrantedItems(ctx, c, h.Cards, cardList, (*v1Card.CardsResponse_Card).GetBoardId, cards.ToChild, "READ"); !ok {
+		return
+	}
+
+	httputil.JSONWithETag(c, http.StatusOK, CreateFullBoard(board, categoryList, cardList, tagList))
+}
+
 // @Summary Update Board
 // @Tags Boards
 // @ModuleID updateBoard
@@ -148,7 +248,7 @@ func (h *Handler) read(c *gin.Context) {
 // @Router /boards/{board_id} [patch]
 // @Security BearerAuth
 func (h *Handler) update(c *gin.Context) {
-	ctx := context.TODO()
+	ctx := httputil.GRPCAuth(c)
 
 	h.Log.Debug("board handler::update bind")
 	var dto UpdateBoardDTO
@@ -170,15 +270,18 @@ func (h *Handler) update(c *gin.Context) {
 }
 
 // @Summary Delete Board
+// @Description The board is moved to the trash, its cards, categories and tags are deleted when the trash entry expires.
+// @Description The cleanup is scheduled until then and its progress is reported by the Location.
 // @Tags Boards
 // @ModuleID deleteBoard
 // @Param board_id path string true "Board ID" format(uuid)
-// @Success 204
+// @Success 202
+// @Header 202 {string} Location "/boards/{board_id}/deletion"
 // @Failure 400,401,403,404,500 {object} httputil.APIError
 // @Router /boards/{board_id} [delete]
 // @Security BearerAuth
 func (h *Handler) delete(c *gin.Context) {
-	ctx := context.TODO()
+	ctx := httputil.GRPCAuth(c)
 
 	h.Log.Debug("board handler::delete bind")
 	var dto DeleteBoardDTO
@@ -186,21 +289,89 @@ func (h *Handler) delete(c *gin.Context) {
 		return
 	}
 
-	if !h.IsGranted(ctx, c, dto.BoardID, "DELETE") {
+	board, ok := h.GrantedBoard(ctx, c, dto.BoardID, "DELETE")
+	if !ok {
+		return
+	}
+
+	saga := handlers.NewSaga(h.Log)
+	entry := toTrash(board, httputil.GetUserID(c))
+
+	h.Log.Debug("board handler::delete move to trash")
+	if err := saga.Do(ctx, "PutTrash", func(ctx context.Context) error {
+		return h.Trash.Put(ctx, entry, toRecreate(board))
+	}, func(ctx context.Context) error {
+		return h.Trash.Remove(ctx, entry)
+	}); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	h.Log.Debug("board handler::delete schedule cleanup")
+	if err := saga.Do(ctx, "ScheduleDeletion", func(ctx context.Context) error {
+		return h.Deletion.Schedule(ctx, dto.BoardID, entry.DeletedBy, time.Now().Add(h.Trash.Retention))
+	}, func(ctx context.Context) error {
+		return h.Deletion.Cancel(ctx, dto.BoardID)
+	}); err != nil {
+		_ = c.Error(err)
 		return
 	}
 
 	h.Log.Debug("board handler::delete call gRPC /BoardClient/DeleteBoard")
-	if _, err := h.BoardService.DeleteBoard(ctx, dto.toDelete()); err != nil {
+	if err := saga.Do(ctx, "DeleteBoard", func(ctx context.Context) error {
+		_, err := h.BoardService.DeleteBoard(ctx, dto.toDelete())
+		return err
+	}, func(ctx context.Context) error {
+		_, err := h.BoardService.CreateBoard(ctx, toRecreate(board))
+		return err
+	}); err != nil {
 		_ = c.Error(err)
 		return
 	}
 
 	h.Log.Debug("board handler::delete call gRPC /SubjectClient/DeleteRef")
-	if _, err := h.SubjectService.DeleteRef(ctx, dto.toDeleteRef()); err != nil {
+	if err := saga.Do(ctx, "DeleteRef", func(ctx context.Context) error {
+		_, err := h.SubjectService.DeleteRef(ctx, dto.toDeleteRef())
+		return err
+	}, nil); err != nil {
 		_ = c.Error(err)
 		return
 	}
 
-	httputil.NoContent(c)
+	c.Header("Location", strings.TrimRight(c.Request.URL.Path, "/")+"/deletion")
+	c.Status(http.StatusAccepted)
+}
+
+// @Summary Board Deletion Progress
+// @Description Progress of the cleanup of a deleted board, reported to the user who deleted it. The cleanup is
+// @Description scheduled while the board is in the trash and runs when the trash entry expires, not before scheduled_at.
+// @Description It is not found once the board is restored.
+// @Tags Boards
+// @ModuleID readBoardDeletion
+// @Produce json
+// @Param board_id path string true "Board ID" format(uuid)
+// @Success 200 {object} jobs.Deletion
+// @Failure 400,401,404,500 {object} httputil.APIError
+// @Router /boards/{board_id}/deletion [get]
+// @Security BearerAuth
+func (h *Handler) deletion(c *gin.Context) {
+	ctx := httputil.GRPCAuth(c)
+
+	h.Log.Debug("board handler::deletion bind")
+	var dto ReadBoardDTO
+	if !binding.BindUriAndValidate(c, &dto) {
+		return
+	}
+
+	d, err := h.Deletion.Get(ctx, dto.BoardID)
+	if err == jobs.ErrNotFound || (err == nil && d.RequestedBy != httputil.GetUserID(c)) {
+		_ = c.Error(httputil.ErrNotFound)
+		return
+	}
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, d)
 }
@@ -2,9 +2,17 @@ package boards
 
 import (
 	"github.com/go-funcards/funapi/internal/gin/httputil"
+	"github.com/go-funcards/funapi/internal/handlers/v1/cards"
+	"github.com/go-funcards/funapi/internal/handlers/v1/categories"
+	"github.com/go-funcards/funapi/internal/handlers/v1/tags"
+	"github.com/go-funcards/funapi/internal/trash"
 	v1Authz "github.com/go-funcards/funapi/proto/authz_service/v1"
 	"github.com/go-funcards/funapi/proto/board_service/v1"
+	v1Card "github.com/go-funcards/funapi/proto/card_service/v1"
+	v1Category "github.com/go-funcards/funapi/proto/category_service/v1"
+	v1Tag "github.com/go-funcards/funapi/proto/tag_service/v1"
 	"github.com/go-funcards/slice"
+	"sort"
 	"time"
 )
 
@@ -26,13 +34,14 @@ type Board struct {
 
 type PageRequest struct {
 	httputil.PageRequest
-	UserID string `json:"-" ctx:"user_id" validate:"required,uuid4"`
+	UserID string        `json:"-" ctx:"user_id" validate:"required,uuid4"`
+	Sort   httputil.Sort `json:"-" form:"sort" validate:"omitempty,max=200,sort=created_at name type"`
 }
 
-func (dto PageRequest) toRead() *v1.BoardsRequest {
+func (dto PageRequest) toRead(index uint64, size uint32) *v1.BoardsRequest {
 	return &v1.BoardsRequest{
-		PageIndex: dto.Index,
-		PageSize:  dto.Size,
+		PageIndex: index,
+		PageSize:  size,
 		OwnerIds:  []string{dto.UserID},
 		MemberIds: []string{dto.UserID},
 	}
@@ -43,6 +52,21 @@ type PageResponse struct {
 	Data []Board `json:"data"`
 }
 
+type FullCategory struct {
+	categories.Category
+	Cards []cards.Card `json:"cards"`
+}
+
+// FullBoard is the board with all its contents, categories and their cards are ordered by position.
+type FullBoard struct {
+	Board
+	Categories []FullCategory `json:"categories"`
+	// Uncategorized are cards of categories missing on the board
+	Uncategorized []cards.Card `json:"uncategorized"`
+	// Tags are keyed by ID
+	Tags map[string]tags.Tag `json:"tags"`
+}
+
 type CreateBoardDTO struct {
 	OwnerID     string `json:"-" ctx:"user_id" validate:"required,uuid4"`
 	Name        string `json:"name" validate:"required,max=150"`
@@ -98,19 +122,62 @@ func (dto DeleteBoardDTO) toDeleteRef() *v1Authz.DeleteRefRequest {
 	}
 }
 
+// toRecreate restores a deleted board.
+func toRecreate(board *v1.BoardsResponse_Board) *v1.CreateBoardRequest {
+	return &v1.CreateBoardRequest{
+		BoardId:     board.GetBoardId(),
+		OwnerId:     board.GetOwnerId(),
+		Name:        board.GetName(),
+		Description: board.GetDescription(),
+		Data:        board.GetData(),
+		Type:        board.GetType(),
+		Members: slice.Map(board.GetMembers(), func(m *v1.BoardsResponse_Board_Member) *v1.CreateBoardRequest_Member {
+			return &v1.CreateBoardRequest_Member{
+				MemberId: m.GetMemberId(),
+				Roles:    slice.Copy(m.GetRoles()),
+			}
+		}),
+	}
+}
+
+func toTrash(board *v1.BoardsResponse_Board, userID string) trash.Entry {
+	return trash.Entry{
+		ID:        board.GetBoardId(),
+		Type:      trash.TypeBoard,
+		BoardID:   board.GetBoardId(),
+		Name:      board.GetName(),
+		DeletedBy: userID,
+	}
+}
+
 func PageReq() PageRequest {
 	return PageRequest{PageRequest: httputil.PageRequest{Size: 1}}
 }
 
-func PageResp(response *v1.BoardsResponse, req PageRequest) PageResponse {
+func PageResp(items []*v1.BoardsResponse_Board, page httputil.PageResponse, req PageRequest) PageResponse {
+	data := slice.Map(items, func(b *v1.BoardsResponse_Board) Board {
+		return CreateBoard(b)
+	})
+	httputil.SortSlice(data, req.Sort, compareBoards)
+
 	return PageResponse{
-		PageResponse: req.ToPageResponse(response.GetTotal()),
-		Data: slice.Map(response.GetBoards(), func(b *v1.BoardsResponse_Board) Board {
-			return CreateBoard(b)
-		}),
+		PageResponse: page,
+		Data:         data,
 	}
 }
 
+func compareBoards(a, b Board, field string) int {
+	switch field {
+	case "name":
+		return httputil.CompareStrings(a.Name, b.Name)
+	case "type":
+		return httputil.CompareStrings(a.Type, b.Type)
+	case "created_at":
+		return httputil.CompareTimes(a.CreatedAt, b.CreatedAt)
+	}
+	return 0
+}
+
 func CreateBoard(response *v1.BoardsResponse_Board) Board {
 	return Board{
 		BoardID:     response.GetBoardId(),
@@ -128,3 +195,51 @@ func CreateBoard(response *v1.BoardsResponse_Board) Board {
 		}),
 	}
 }
+
+func CreateFullBoard(
+	board *v1.BoardsResponse_Board,
+	categoryList []*v1Category.CategoriesResponse_Category,
+	cardList []*v1Card.CardsResponse_Card,
+	tagList []*v1Tag.TagsResponse_Tag,
+) FullBoard {
+	sort.SliceStable(categoryList, func(i, j int) bool {
+		if categoryList[i].GetPosition() == categoryList[j].GetPosition() {
+			return categoryList[i].GetCategoryId() < categoryList[j].GetCategoryId()
+		}
+		return categoryList[i].GetPosition() < categoryList[j].GetPosition()
+	})
+	sort.SliceStable(cardList, func(i, j int) bool {
+		if cardList[i].GetPosition() == cardList[j].GetPosition() {
+			return cardList[i].GetCardId() < cardList[j].GetCardId()
+		}
+		return cardList[i].GetPosition() < cardList[j].GetPosition()
+	})
+
+	full := FullBoard{
+		Board:         CreateBoard(board),
+		Categories:    make([]FullCategory, len(categoryList)),
+		Uncategorized: []cards.Card{},
+		Tags:          make(map[string]tags.Tag, len(tagList)),
+	}
+
+	index := make(map[string]int, len(categoryList))
+	for i, category := range categoryList {
+		index[category.GetCategoryId()] = i
+		full.Categories[i] = FullCategory{
+			Category: categories.CreateCategory(category),
+			Cards:    []cards.Card{},
+		}
+	}
+	for _, card := range cardList {
+		if i, ok := index[card.GetCategoryId()]; ok {
+			full.Categories[i].Cards = append(full.Categories[i].Cards, cards.CreateCard(card))
+		} else {
+			full.Uncategorized = append(full.Uncategorized, cards.CreateCard(card))
+		}
+	}
+	for _, tag := range tagList {
+		full.Tags[tag.GetTagId()] = tags.CreateTag(tag)
+	}
+
+	return full
+}
@@ -2,12 +2,16 @@ package cards
 
 import (
 	"context"
+	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/go-funcards/funapi/internal/gin/binding"
 	"github.com/go-funcards/funapi/internal/gin/httputil"
 	"github.com/go-funcards/funapi/internal/handlers"
 	"github.com/go-funcards/funapi/internal/handlers/v1/clientutil"
+	"github.com/go-funcards/funapi/internal/search"
+	"github.com/go-funcards/funapi/internal/trash"
 	v1Card "github.com/go-funcards/funapi/proto/card_service/v1"
+	v1Category "github.com/go-funcards/funapi/proto/category_service/v1"
 	"github.com/go-funcards/slice"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -18,8 +22,11 @@ var _ handlers.Handler = (*Handler)(nil)
 
 type Handler struct {
 	*handlers.BaseBoard
-	CardService v1Card.CardClient
-	Log         *zap.Logger
+	CardService     v1Card.CardClient
+	CategoryService v1Category.CategoryClient
+	Search          *search.Index
+	Trash           *trash.Store
+	Log             *zap.Logger
 }
 
 func (h *Handler) Register(rg *gin.RouterGroup) {
@@ -27,25 +34,35 @@ func (h *Handler) Register(rg *gin.RouterGroup) {
 	{
 		g.GET("", h.list)
 		g.POST("", h.create)
+		g.POST("/batch", h.createMany)
 		g.PATCH("", h.updateMany)
+		g.DELETE("", h.deleteMany)
 
 		b := g.Group("/:card_id")
 		{
 			b.GET("", h.read)
 			b.PATCH("", h.update)
 			b.DELETE("", h.delete)
+			b.POST("/move", h.move)
 		}
 	}
 }
 
 // @Summary Card List
+// @Description Cards the user may not read are omitted, so a page may contain fewer cards than page_size.
 // @Tags Cards
 // @ModuleID listCard
 // @Accept json
 // @Produce json
-// @Param board_id query string true "Board ID" format(uuid)
+// @Param board_id query []string true "Board IDs" collectionFormat(multi) maxItems(100)
+// @Param category_id query []string false "Category IDs" collectionFormat(multi) maxItems(100)
+// @Param tag_id query []string false "Tag IDs" collectionFormat(multi) maxItems(100)
+// @Param owner_id query []string false "Owner IDs" collectionFormat(multi) maxItems(100)
+// @Param type query []string false "Card Types" collectionFormat(multi) Enums(UNK_CARD, TEXT)
 // @Param page_index query int false "Page Index" minimum(0)
 // @Param page_size query int false "Page Size" minimum(1) maximum(1000)
+// @Param cursor query string false "Cursor of the next page, takes precedence over page_index. It holds the offset and the ID of the last returned item, not a sort key, so it cannot be combined with sort"
+// @Param sort query string false "Comma separated fields, prefixed with - for descending order: created_at, name, position, type. Supported only when the result fits on the first page"
 // @Success 200 {object} cards.PageResponse
 // @Failure 400,401,403,500 {object} httputil.APIError
 // @Router /cards [get]
@@ -57,20 +74,38 @@ func (h *Handler) list(c *gin.Context) {
 		return
 	}
 
-	ctx := context.TODO()
+	if err := req.Sort.CheckFirstPage(req.PageRequest); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	ctx := httputil.GRPCAuth(c)
 
-	if !h.IsGranted(ctx, c, req.BoardID, "READ") {
+	if !h.AreGranted(ctx, c, req.BoardIDs, "READ") {
 		return
 	}
 
-	h.Log.Debug("card handler::list call gRPC /CardClient/GetCards")
-	response, err := h.CardService.GetCards(ctx, req.toRead())
+	items, page, err := httputil.Paginate(c, req.PageRequest, func(index uint64, size uint32) ([]*v1Card.CardsResponse_Card, uint64, error) {
+		h.Log.Debug("card handler::list call gRPC /CardClient/GetCards")
+		response, err := h.CardService.GetCards(ctx, req.toRead(index, size))
+		return response.GetCards(), response.GetTotal(), err
+	}, (*v1Card.CardsResponse_Card).GetCardId)
 	if err != nil {
 		_ = c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, PageResp(response, req))
+	if err = req.Sort.CheckSortable(req.PageRequest, page.Total); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	items, ok := handlers.GrantedItems(ctx, c, h.BaseBoard, items, (*v1Card.CardsResponse_Card).GetBoardId, ToChild, "READ")
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, PageResp(items, page, req))
 }
 
 // @Summary Create Card
@@ -90,7 +125,7 @@ func (h *Handler) create(c *gin.Context) {
 		return
 	}
 
-	ctx := context.TODO()
+	ctx := httputil.GRPCAuth(c)
 
 	if !h.IsGranted(ctx, c, dto.BoardID, "CREATE") {
 		return
@@ -104,6 +139,8 @@ func (h *Handler) create(c *gin.Context) {
 		return
 	}
 
+	h.reindex(ctx, id)
+
 	httputil.Created(c, id)
 }
 
@@ -123,7 +160,7 @@ func (h *Handler) updateMany(c *gin.Context) {
 		return
 	}
 
-	ctx := context.TODO()
+	ctx := httputil.GRPCAuth(c)
 
 	h.Log.Debug("card handler::updateMany call gRPC /CardClient/GetCards")
 	data, err := h.CardService.GetCards(ctx, &v1Card.CardsRequest{
@@ -138,15 +175,14 @@ func (h *Handler) updateMany(c *gin.Context) {
 		return
 	}
 
-	boards := make(map[string]bool)
+	found := data.GetCards()
+	if !h.AreChildrenGranted(ctx, c, slice.Map(found, (*v1Card.CardsResponse_Card).GetBoardId), slice.Map(found, ToChild), "UPDATE") {
+		return
+	}
 
-	for _, card := range data.GetCards() {
-		if _, ok := boards[card.GetBoardId()]; !ok {
-			boards[card.GetBoardId()] = true
-			if !h.IsGranted(ctx, c, card.GetBoardId(), "UPDATE") {
-				return
-			}
-		}
+	boards := make(map[string]bool)
+	for _, card := range found {
+		boards[card.GetBoardId()] = true
 	}
 
 	for _, item := range dto.Data {
@@ -164,9 +200,85 @@ func (h *Handler) updateMany(c *gin.Context) {
 		return
 	}
 
+	h.reindex(ctx, slice.Map(dto.Data, func(item UpdateCardDTO) string {
+		return item.CardID
+	})...)
+
 	httputil.NoContent(c)
 }
 
+// @Summary Create Many Cards
+// @Description Items are validated and created one by one, CREATE is checked once per board.
+// @Tags Cards
+// @ModuleID createManyCards
+// @Accept json
+// @Produce json
+// @Param payload body cards.CreateManyCardsDTO true "Cards data"
+// @Success 207 {object} httputil.BatchResponse
+// @Failure 400,401,422,500 {object} httputil.APIError
+// @Router /cards/batch [post]
+// @Security BearerAuth
+func (h *Handler) createMany(c *gin.Context) {
+	h.Log.Debug("card handler::createMany bind")
+	var dto CreateManyCardsDTO
+	if !binding.BindCtx(c, &dto) || !binding.BindBodyAndValidate(c, &dto) {
+		return
+	}
+
+	ctx := httputil.GRPCAuth(c)
+
+	h.Log.Debug("card handler::createMany call gRPC /CardClient/CreateCard")
+	results := handlers.CreateMany(ctx, c, h.BaseBoard, dto.items(), func(item CreateCardDTO) string {
+		return item.BoardID
+	}, func(ctx context.Context, item CreateCardDTO, id string) error {
+		_, err := h.CardService.CreateCard(ctx, item.toCreate(id))
+		return err
+	})
+
+	h.reindex(ctx, handlers.CreatedIDs(results)...)
+
+	httputil.MultiStatus(c, results)
+}
+
+// @Summary Delete Many Cards
+// @Description Cards are moved to the trash, DELETE is checked per card.
+// @Tags Cards
+// @ModuleID deleteManyCards
+// @Accept json
+// @Produce json
+// @Param payload body cards.DeleteManyCardsDTO true "Cards IDs"
+// @Success 207 {object} httputil.BatchResponse
+// @Failure 400,401,422,500 {object} httputil.APIError
+// @Router /cards [delete]
+// @Security BearerAuth
+func (h *Handler) deleteMany(c *gin.Context) {
+	h.Log.Debug("card handler::deleteMany bind")
+	var dto DeleteManyCardsDTO
+	if !binding.BindBodyAndValidate(c, &dto) {
+		return
+	}
+
+	ctx := httputil.GRPCAuth(c)
+	userID := httputil.GetUserID(c)
+
+	h.Log.Debug("card handler::deleteMany call gRPC /CardClient/GetCards")
+	response, err := h.CardService.GetCards(ctx, dto.toRead())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	results := handlers.DeleteMany(ctx, c, h.BaseBoard, dto.IDs, response.GetCards(),
+		(*v1Card.CardsResponse_Card).GetCardId,
+		(*v1Card.CardsResponse_Card).GetBoardId,
+		ToChild,
+		func(ctx context.Context, card *v1Card.CardsResponse_Card) error {
+			return h.moveToTrash(ctx, userID, card)
+		})
+
+	httputil.MultiStatus(c, results)
+}
+
 // @Summary Read Card
 // @Tags Cards
 // @ModuleID readCard
@@ -184,7 +296,7 @@ func (h *Handler) read(c *gin.Context) {
 		return
 	}
 
-	ctx := context.TODO()
+	ctx := httputil.GRPCAuth(c)
 
 	h.Log.Debug("card handler::read call gRPC /CardClient/GetCard")
 	card, err := clientutil.GetCard(ctx, h.CardService, dto.CardID)
@@ -193,7 +305,7 @@ func (h *Handler) read(c *gin.Context) {
 		return
 	}
 
-	if !h.IsGranted(ctx, c, card.GetBoardId(), "READ") {
+	if !h.IsGranted(ctx, c, card.GetBoardId(), "READ", ToChild(card)) {
 		return
 	}
 
@@ -217,7 +329,7 @@ func (h *Handler) update(c *gin.Context) {
 		return
 	}
 
-	ctx := context.TODO()
+	ctx := httputil.GRPCAuth(c)
 
 	h.Log.Debug("card handler::update call gRPC /CardClient/GetCard")
 	card, err := clientutil.GetCard(ctx, h.CardService, dto.CardID)
@@ -226,7 +338,7 @@ func (h *Handler) update(c *gin.Context) {
 		return
 	}
 
-	if !h.IsGranted(ctx, c, card.GetBoardId(), "UPDATE") {
+	if !h.IsGranted(ctx, c, card.GetBoardId(), "UPDATE", ToChild(card)) {
 		return
 	}
 
@@ -240,10 +352,103 @@ func (h *Handler) update(c *gin.Context) {
 		return
 	}
 
+	h.reindex(ctx, dto.CardID)
+
+	httputil.NoContent(c)
+}
+
+// @Summary Move Card
+// @Description Place the card before or after anchor cards of the target category, at its end without anchors.
+// @Description The category must belong to the target board.
+// @Description Positions are computed by the server, cards of the category are rebalanced when needed,
+// @Description which requires UPDATE on them.
+// @Tags Cards
+// @ModuleID moveCard
+// @Accept json
+// @Param card_id path string true "Card ID" format(uuid)
+// @Param payload body cards.MoveCardDTO true "Target"
+// @Success 204
+// @Failure 400,401,403,404,409,422,500 {object} httputil.APIError
+// @Router /cards/{card_id}/move [post]
+// @Security BearerAuth
+func (h *Handler) move(c *gin.Context) {
+	h.Log.Debug("card handler::move bind")
+	var dto MoveCardDTO
+	if !binding.BindBody(c, &dto) || !binding.BindUriAndValidate(c, &dto) {
+		return
+	}
+
+	ctx := httputil.GRPCAuth(c)
+
+	h.Log.Debug("card handler::move call gRPC /CardClient/GetCard")
+	card, err := clientutil.GetCard(ctx, h.CardService, dto.CardID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if !h.IsGranted(ctx, c, card.GetBoardId(), "UPDATE", ToChild(card)) {
+		return
+	}
+
+	boardID := dto.targetBoardID(card)
+	if boardID != card.GetBoardId() && !h.IsGranted(ctx, c, boardID, "CREATE") {
+		return
+	}
+
+	h.Log.Debug("card handler::move call gRPC /CategoryClient/GetCategories")
+	category, err := clientutil.GetCategory(ctx, h.CategoryService, dto.CategoryID)
+	if err != nil && !errors.Is(err, httputil.ErrNotFound) {
+		_ = c.Error(err)
+		return
+	}
+	if err != nil || category.GetBoardId() != boardID {
+		_ = c.Error(httputil.NewAPIError(http.StatusUnprocessableEntity, "entity_validation", gin.H{
+			"category_id": "category is not in the target board",
+		}))
+		return
+	}
+
+	h.Log.Debug("card handler::move call gRPC /CardClient/GetCards")
+	cards, err := clientutil.GetAllCards(ctx, h.CardService, &v1Card.CardsRequest{
+		BoardIds:    []string{boardID},
+		CategoryIds: []string{dto.CategoryID},
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	req, err := dto.toMove(card, cards)
+	var anchor anchorError
+	if errors.As(err, &anchor) {
+		err = httputil.NewAPIError(http.StatusUnprocessableEntity, "entity_validation", gin.H{
+			anchor.field: "card is not in the target category",
+		})
+	}
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if others := rebalanced(req, card, cards); len(others) > 0 &&
+		!h.AreChildrenGranted(ctx, c, slice.Map(others, (*v1Card.CardsResponse_Card).GetBoardId), slice.Map(others, ToChild), "UPDATE") {
+		return
+	}
+
+	h.Log.Debug("card handler::move call gRPC /CardClient/UpdateManyCards")
+	if _, err = h.CardService.UpdateManyCards(ctx, req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	h.reindex(ctx, dto.CardID)
+
 	httputil.NoContent(c)
 }
 
 // @Summary Delete Card
+// @Description The card is moved to the trash.
 // @Tags Cards
 // @ModuleID deleteCard
 // @Param card_id path string true "Card ID" format(uuid)
@@ -258,7 +463,7 @@ func (h *Handler) delete(c *gin.Context) {
 		return
 	}
 
-	ctx := context.TODO()
+	ctx := httputil.GRPCAuth(c)
 
 	h.Log.Debug("card handler::delete call gRPC /CardClient/GetCard")
 	card, err := clientutil.GetCard(ctx, h.CardService, dto.CardID)
@@ -267,15 +472,60 @@ func (h *Handler) delete(c *gin.Context) {
 		return
 	}
 
-	if !h.IsGranted(ctx, c, card.GetBoardId(), "DELETE") {
+	if !h.IsGranted(ctx, c, card.GetBoardId(), "DELETE", ToChild(card)) {
 		return
 	}
 
-	h.Log.Debug("card handler::delete call gRPC /CardClient/DeleteCard")
-	if _, err = h.CardService.DeleteCard(ctx, dto.toDelete()); err != nil {
+	if err = h.moveToTrash(ctx, httputil.GetUserID(c), card); err != nil {
 		_ = c.Error(err)
 		return
 	}
 
 	httputil.NoContent(c)
 }
+
+// moveToTrash deletes the card, keeping it in the trash for restore.
+func (h *Handler) moveToTrash(ctx context.Context, userID string, card *v1Card.CardsResponse_Card) error {
+	saga := handlers.NewSaga(h.Log)
+	entry := toTrash(card, userID)
+
+	h.Log.Debug("card handler::moveToTrash put entry")
+	if err := saga.Do(ctx, "PutTrash", func(ctx context.Context) error {
+		return h.Trash.Put(ctx, entry, toRecreate(card))
+	}, func(ctx context.Context) error {
+		return h.Trash.Remove(ctx, entry)
+	}); err != nil {
+		return err
+	}
+
+	h.Log.Debug("card handler::moveToTrash call gRPC /CardClient/DeleteCard")
+	if err := saga.Do(ctx, "DeleteCard", func(ctx context.Context) error {
+		_, err := h.CardService.DeleteCard(ctx, &v1Card.DeleteCardRequest{CardId: card.GetCardId()})
+		return err
+	}, nil); err != nil {
+		return err
+	}
+
+	h.Search.Remove(ctx, card.GetCardId())
+	return nil
+}
+
+// reindex feeds the search index with the current state of the cards.
+func (h *Handler) reindex(ctx context.Context, ids ...string) {
+	if len(ids) == 0 {
+		return
+	}
+
+	h.Log.Debug("card handler::reindex call gRPC /CardClient/GetCards")
+	response, err := h.CardService.GetCards(ctx, &v1Card.CardsRequest{
+		PageIndex: 0,
+		PageSize:  uint32(len(ids)),
+		CardIds:   ids,
+	})
+	if err != nil {
+		h.Log.Error("card handler::reindex", zap.Error(err))
+		return
+	}
+
+	h.Search.Add(ctx, slice.Map(response.GetCards(), search.CardDocument)...)
+}
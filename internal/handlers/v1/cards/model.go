@@ -1,9 +1,13 @@
 package cards
 
 import (
+	v1Authz "github.com/go-funcards/funapi/internal/client/authz_service/v1"
 	"github.com/go-funcards/funapi/internal/gin/httputil"
+	"github.com/go-funcards/funapi/internal/handlers"
+	"github.com/go-funcards/funapi/internal/trash"
 	"github.com/go-funcards/funapi/proto/card_service/v1"
 	"github.com/go-funcards/slice"
+	"sort"
 	"time"
 )
 
@@ -33,14 +37,25 @@ type PageResponse struct {
 
 type PageRequest struct {
 	httputil.PageRequest
-	BoardID string `json:"-" form:"board_id" validate:"required,uuid4"`
+	BoardIDs    []string      `json:"-" form:"board_id" validate:"required,min=1,max=100,unique,dive,uuid4"`
+	CategoryIDs []string      `json:"-" form:"category_id" validate:"omitempty,max=100,unique,dive,uuid4"`
+	TagIDs      []string      `json:"-" form:"tag_id" validate:"omitempty,max=100,unique,dive,uuid4"`
+	OwnerIDs    []string      `json:"-" form:"owner_id" validate:"omitempty,max=100,unique,dive,uuid4"`
+	Types       []string      `json:"-" form:"type" validate:"omitempty,unique,dive,oneof=UNK_CARD TEXT"`
+	Sort        httputil.Sort `json:"-" form:"sort" validate:"omitempty,max=200,sort=created_at name position type"`
 }
 
-func (dto PageRequest) toRead() *v1.CardsRequest {
+func (dto PageRequest) toRead(index uint64, size uint32) *v1.CardsRequest {
 	return &v1.CardsRequest{
-		PageIndex: dto.Index,
-		PageSize:  dto.Size,
-		BoardIds:  []string{dto.BoardID},
+		PageIndex:   index,
+		PageSize:    size,
+		BoardIds:    dto.BoardIDs,
+		CategoryIds: dto.CategoryIDs,
+		Tags:        dto.TagIDs,
+		OwnerIds:    dto.OwnerIDs,
+		Types: slice.Map(dto.Types, func(t string) v1.CardType {
+			return v1.CardType(v1.CardType_value[t])
+		}),
 	}
 }
 
@@ -69,6 +84,19 @@ func (dto CreateCardDTO) toCreate(id string) *v1.CreateCardRequest {
 	}
 }
 
+type CreateManyCardsDTO struct {
+	OwnerID string          `json:"-" ctx:"user_id" validate:"required,uuid4"`
+	Data    []CreateCardDTO `json:"data" validate:"required,min=1,max=1000"`
+}
+
+// items returns the items owned by the caller, they are validated one by one.
+func (dto CreateManyCardsDTO) items() []CreateCardDTO {
+	return slice.Map(dto.Data, func(item CreateCardDTO) CreateCardDTO {
+		item.OwnerID = dto.OwnerID
+		return item
+	})
+}
+
 type UpdateCardDTO struct {
 	CardID     string   `json:"card_id" uri:"card_id" validate:"required,uuid4" format:"uuid"`
 	BoardID    string   `json:"board_id" validate:"omitempty,uuid4" format:"uuid"`
@@ -91,6 +119,132 @@ func (dto UpdateCardDTO) toUpdate() *v1.UpdateCardRequest {
 	}
 }
 
+type MoveCardDTO struct {
+	CardID     string `json:"-" uri:"card_id" validate:"required,uuid4"`
+	BoardID    string `json:"board_id,omitempty" validate:"omitempty,uuid4" format:"uuid"`
+	CategoryID string `json:"category_id" validate:"required,uuid4" format:"uuid"`
+	// Before is the card the moved card is placed before
+	Before string `json:"before,omitempty" validate:"omitempty,uuid4,nefield=CardID" format:"uuid"`
+	// After is the card the moved card is placed after
+	After string `json:"after,omitempty" validate:"omitempty,uuid4,nefield=CardID" format:"uuid"`
+}
+
+func (dto MoveCardDTO) targetBoardID(card *v1.CardsResponse_Card) string {
+	if len(dto.BoardID) > 0 {
+		return dto.BoardID
+	}
+	return card.GetBoardId()
+}
+
+// toMove places the card among cards of the target category. Only the moved card is updated,
+// unless there is no free position between the anchors, then the whole category is rebalanced.
+func (dto MoveCardDTO) toMove(card *v1.CardsResponse_Card, cards []*v1.CardsResponse_Card) (*v1.UpdateManyCardsRequest, error) {
+	others := make([]*v1.CardsResponse_Card, 0, len(cards))
+	for _, item := range cards {
+		if item.GetCardId() != card.GetCardId() {
+			others = append(others, item)
+		}
+	}
+	cards = others
+
+	sort.SliceStable(cards, func(i, j int) bool {
+		if cards[i].GetPosition() == cards[j].GetPosition() {
+			return cards[i].GetCardId() < cards[j].GetCardId()
+		}
+		return cards[i].GetPosition() < cards[j].GetPosition()
+	})
+
+	index, err := dto.index(cards)
+	if err != nil {
+		return nil, err
+	}
+
+	moved := &v1.UpdateCardRequest{
+		CardId:     card.GetCardId(),
+		BoardId:    dto.targetBoardID(card),
+		CategoryId: dto.CategoryID,
+	}
+
+	var prev, next *int32
+	if index > 0 {
+		position := cards[index-1].GetPosition()
+		prev = &position
+	}
+	if index < len(cards) {
+		position := cards[index].GetPosition()
+		next = &position
+	}
+
+	var ok bool
+	if moved.Position, ok = handlers.PositionBetween(prev, next); ok {
+		return &v1.UpdateManyCardsRequest{Cards: []*v1.UpdateCardRequest{moved}}, nil
+	}
+
+	ordered := make([]*v1.CardsResponse_Card, 0, len(cards)+1)
+	ordered = append(ordered, cards[:index]...)
+	ordered = append(ordered, nil)
+	ordered = append(ordered, cards[index:]...)
+
+	req := &v1.UpdateManyCardsRequest{}
+	for i, position := range handlers.Positions(len(ordered)) {
+		if ordered[i] == nil {
+			moved.Position = position
+			req.Cards = append(req.Cards, moved)
+		} else if ordered[i].GetPosition() != position {
+			req.Cards = append(req.Cards, &v1.UpdateCardRequest{
+				CardId:   ordered[i].GetCardId(),
+				Position: position,
+			})
+		}
+	}
+	return req, nil
+}
+
+// anchorError is returned when an anchor card of the move is not in the target category.
+type anchorError struct {
+	field string
+}
+
+func (e anchorError) Error() string {
+	return e.field + ": card is not in the target category"
+}
+
+// index returns the index the card is inserted at, the end of the category without anchors.
+func (dto MoveCardDTO) index(cards []*v1.CardsResponse_Card) (int, error) {
+	indexOf := func(field, id string) (int, error) {
+		for i, item := range cards {
+			if item.GetCardId() == id {
+				return i, nil
+			}
+		}
+		return 0, anchorError{field: field}
+	}
+
+	switch {
+	case len(dto.After) > 0 && len(dto.Before) > 0:
+		after, err := indexOf("after", dto.After)
+		if err != nil {
+			return 0, err
+		}
+		before, err := indexOf("before", dto.Before)
+		if err != nil {
+			return 0, err
+		}
+		if after+1 != before {
+			// the client does not see the current order of the category
+			return 0, httputil.ErrConflict
+		}
+		return before, nil
+	case len(dto.After) > 0:
+		after, err := indexOf("after", dto.After)
+		return after + 1, err
+	case len(dto.Before) > 0:
+		return indexOf("before", dto.Before)
+	default:
+		return len(cards), nil
+	}
+}
+
 type UpdateManyCardsDTO struct {
 	Data []UpdateCardDTO `json:"data" validate:"required,min=1,dive"`
 }
@@ -107,13 +261,72 @@ type ReadCardDTO struct {
 	CardID string `json:"-" uri:"card_id" validate:"required,uuid4"`
 }
 
+type DeleteManyCardsDTO struct {
+	IDs []string `json:"ids" validate:"required,min=1,max=1000,unique,dive,uuid4"`
+}
+
+func (dto DeleteManyCardsDTO) toRead() *v1.CardsRequest {
+	return &v1.CardsRequest{
+		PageIndex: 0,
+		PageSize:  uint32(len(dto.IDs)),
+		CardIds:   dto.IDs,
+	}
+}
+
 type DeleteCardDTO struct {
 	CardID string `json:"-" uri:"card_id" validate:"required,uuid4"`
 }
 
-func (dto DeleteCardDTO) toDelete() *v1.DeleteCardRequest {
-	return &v1.DeleteCardRequest{
-		CardId: dto.CardID,
+// rebalanced returns the cards other than the moved card repositioned by the request.
+func rebalanced(req *v1.UpdateManyCardsRequest, card *v1.CardsResponse_Card, cards []*v1.CardsResponse_Card) []*v1.CardsResponse_Card {
+	ids := make([]string, 0, len(req.GetCards()))
+	for _, item := range req.GetCards() {
+		if item.GetCardId() != card.GetCardId() {
+			ids = append(ids, item.GetCardId())
+		}
+	}
+	others := make([]*v1.CardsResponse_Card, 0, len(ids))
+	for _, item := range cards {
+		if slice.Contains(ids, item.GetCardId()) {
+			others = append(others, item)
+		}
+	}
+	return others
+}
+
+// ToChild returns the authz object of the card, as a child of its board.
+func ToChild(card *v1.CardsResponse_Card) v1Authz.Child {
+	return v1Authz.Child{ID: card.GetCardId(), Owner: card.GetOwnerId()}
+}
+
+func toTrash(card *v1.CardsResponse_Card, userID string) trash.Entry {
+	return trash.Entry{
+		ID:        card.GetCardId(),
+		Type:      trash.TypeCard,
+		BoardID:   card.GetBoardId(),
+		Name:      card.GetName(),
+		DeletedBy: userID,
+	}
+}
+
+// toRecreate restores a deleted card.
+func toRecreate(card *v1.CardsResponse_Card) *v1.CreateCardRequest {
+	return &v1.CreateCardRequest{
+		CardId:     card.GetCardId(),
+		OwnerId:    card.GetOwnerId(),
+		BoardId:    card.GetBoardId(),
+		CategoryId: card.GetCategoryId(),
+		Name:       card.GetName(),
+		Content:    card.GetContent(),
+		Position:   card.GetPosition(),
+		Type:       card.GetType(),
+		Tags:       slice.Copy(card.GetTags()),
+		Attachments: slice.Map(card.GetAttachments(), func(a *v1.CardsResponse_Card_Attachment) *v1.CreateCardRequest_Att {
+			return &v1.CreateCardRequest_Att{
+				AttachmentId: a.GetAttachmentId(),
+				Type:         a.GetType(),
+			}
+		}),
 	}
 }
 
@@ -121,13 +334,30 @@ func PageReq() PageRequest {
 	return PageRequest{PageRequest: httputil.PageRequest{Size: 1}}
 }
 
-func PageResp(response *v1.CardsResponse, req PageRequest) PageResponse {
+func PageResp(items []*v1.CardsResponse_Card, page httputil.PageResponse, req PageRequest) PageResponse {
+	data := slice.Map(items, func(b *v1.CardsResponse_Card) Card {
+		return CreateCard(b)
+	})
+	httputil.SortSlice(data, req.Sort, compareCards)
+
 	return PageResponse{
-		PageResponse: req.ToPageResponse(response.GetTotal()),
-		Data: slice.Map(response.GetCards(), func(b *v1.CardsResponse_Card) Card {
-			return CreateCard(b)
-		}),
+		PageResponse: page,
+		Data:         data,
+	}
+}
+
+func compareCards(a, b Card, field string) int {
+	switch field {
+	case "name":
+		return httputil.CompareStrings(a.Name, b.Name)
+	case "type":
+		return httputil.CompareStrings(a.Type, b.Type)
+	case "position":
+		return httputil.CompareInts(a.Position, b.Position)
+	case "created_at":
+		return httputil.CompareTimes(a.CreatedAt, b.CreatedAt)
 	}
+	return 0
 }
 
 func CreateCard(response *v1.CardsResponse_Card) Card {
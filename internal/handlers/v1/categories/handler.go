@@ -7,6 +7,8 @@ import (
 	"github.com/go-funcards/funapi/internal/gin/httputil"
 	"github.com/go-funcards/funapi/internal/handlers"
 	"github.com/go-funcards/funapi/internal/handlers/v1/clientutil"
+	"github.com/go-funcards/funapi/internal/search"
+	"github.com/go-funcards/funapi/internal/trash"
 	v1Category "github.com/go-funcards/funapi/proto/category_service/v1"
 	"github.com/go-funcards/slice"
 	"github.com/google/uuid"
@@ -14,20 +16,36 @@ import (
 	"net/http"
 )
 
-var _ handlers.Handler = (*Handler)(nil)
+var (
+	_ handlers.Handler = (*Handler)(nil)
+	_ handlers.Handler = (*OrderHandler)(nil)
+)
 
 type Handler struct {
 	*handlers.BaseBoard
 	CategoryService v1Category.CategoryClient
+	Search          *search.Index
+	Trash           *trash.Store
 	Log             *zap.Logger
 }
 
+// OrderHandler registers ordering of categories on routes of a board.
+type OrderHandler struct {
+	*Handler
+}
+
+func (h *OrderHandler) Register(rg *gin.RouterGroup) {
+	rg.POST("/categories/order", h.order)
+}
+
 func (h *Handler) Register(rg *gin.RouterGroup) {
 	g := rg.Group("/categories")
 	{
 		g.GET("", h.list)
 		g.POST("", h.create)
+		g.POST("/batch", h.createMany)
 		g.PATCH("", h.updateMany)
+		g.DELETE("", h.deleteMany)
 
 		b := g.Group("/:category_id")
 		{
@@ -39,13 +57,18 @@ func (h *Handler) Register(rg *gin.RouterGroup) {
 }
 
 // @Summary Category List
+// @Description Categories the user may not read are omitted, so a page may contain fewer categories than page_size.
 // @Tags Categories
 // @ModuleID listCategory
 // @Accept json
 // @Produce json
-// @Param board_id query string true "Board ID" format(uuid)
+// @Param board_id query []string true "Board IDs" collectionFormat(multi) maxItems(100)
+// @Param category_id query []string false "Category IDs" collectionFormat(multi) maxItems(100)
+// @Param owner_id query []string false "Owner IDs" collectionFormat(multi) maxItems(100)
 // @Param page_index query int false "Page Index" minimum(0)
 // @Param page_size query int false "Page Size" minimum(1) maximum(1000)
+// @Param cursor query string false "Cursor of the next page, takes precedence over page_index. It holds the offset and the ID of the last returned item, not a sort key, so it cannot be combined with sort"
+// @Param sort query string false "Comma separated fields, prefixed with - for descending order: created_at, name, position. Supported only when the result fits on the first page"
 // @Success 200 {object} categories.PageResponse
 // @Failure 400,401,403,500 {object} httputil.APIError
 // @Router /categories [get]
@@ -57,20 +80,38 @@ func (h *Handler) list(c *gin.Context) {
 		return
 	}
 
-	ctx := context.TODO()
+	if err := req.Sort.CheckFirstPage(req.PageRequest); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	ctx := httputil.GRPCAuth(c)
 
-	if !h.IsGranted(ctx, c, req.BoardID, "READ") {
+	if !h.AreGranted(ctx, c, req.BoardIDs, "READ") {
 		return
 	}
 
-	h.Log.Debug("category handler::list call gRPC /CategoryClient/GetCategories")
-	response, err := h.CategoryService.GetCategories(ctx, req.toRead())
+	items, page, err := httputil.Paginate(c, req.PageRequest, func(index uint64, size uint32) ([]*v1Category.CategoriesResponse_Category, uint64, error) {
+		h.Log.Debug("category handler::list call gRPC /CategoryClient/GetCategories")
+		response, err := h.CategoryService.GetCategories(ctx, req.toRead(index, size))
+		return response.GetCategories(), response.GetTotal(), err
+	}, (*v1Category.CategoriesResponse_Category).GetCategoryId)
 	if err != nil {
 		_ = c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, PageResp(response, req))
+	if err = req.Sort.CheckSortable(req.PageRequest, page.Total); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	items, ok := handlers.GrantedItems(ctx, c, h.BaseBoard, items, (*v1Category.CategoriesResponse_Category).GetBoardId, ToChild, "READ")
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, PageResp(items, page, req))
 }
 
 // @Summary Create Category
@@ -90,7 +131,7 @@ func (h *Handler) create(c *gin.Context) {
 		return
 	}
 
-	ctx := context.TODO()
+	ctx := httputil.GRPCAuth(c)
 
 	if !h.IsGranted(ctx, c, dto.BoardID, "CREATE") {
 		return
@@ -104,6 +145,8 @@ func (h *Handler) create(c *gin.Context) {
 		return
 	}
 
+	h.reindex(ctx, id)
+
 	httputil.Created(c, id)
 }
 
@@ -123,7 +166,7 @@ func (h *Handler) updateMany(c *gin.Context) {
 		return
 	}
 
-	ctx := context.TODO()
+	ctx := httputil.GRPCAuth(c)
 
 	h.Log.Debug("category handler::updateMany call gRPC /CategoryClient/GetCategories")
 	data, err := h.CategoryService.GetCategories(ctx, &v1Category.CategoriesRequest{
@@ -138,15 +181,14 @@ func (h *Handler) updateMany(c *gin.Context) {
 		return
 	}
 
-	boards := make(map[string]bool)
+	found := data.GetCategories()
+	if !h.AreChildrenGranted(ctx, c, slice.Map(found, (*v1Category.CategoriesResponse_Category).GetBoardId), slice.Map(found, ToChild), "UPDATE") {
+		return
+	}
 
-	for _, category := range data.GetCategories() {
-		if _, ok := boards[category.GetBoardId()]; !ok {
-			boards[category.GetBoardId()] = true
-			if !h.IsGranted(ctx, c, category.GetBoardId(), "UPDATE") {
-				return
-			}
-		}
+	boards := make(map[string]bool)
+	for _, category := range found {
+		boards[category.GetBoardId()] = true
 	}
 
 	for _, item := range dto.Data {
@@ -164,6 +206,133 @@ func (h *Handler) updateMany(c *gin.Context) {
 		return
 	}
 
+	h.reindex(ctx, slice.Map(dto.Data, func(item UpdateCategoryDTO) string {
+		return item.CategoryID
+	})...)
+
+	httputil.NoContent(c)
+}
+
+// @Summary Create Many Categories
+// @Description Items are validated and created one by one, CREATE is checked once per board.
+// @Tags Categories
+// @ModuleID createManyCategories
+// @Accept json
+// @Produce json
+// @Param payload body categories.CreateManyCategoriesDTO true "Categories data"
+// @Success 207 {object} httputil.BatchResponse
+// @Failure 400,401,422,500 {object} httputil.APIError
+// @Router /categories/batch [post]
+// @Security BearerAuth
+func (h *Handler) createMany(c *gin.Context) {
+	h.Log.Debug("category handler::createMany bind")
+	var dto CreateManyCategoriesDTO
+	if !binding.BindCtx(c, &dto) || !binding.BindBodyAndValidate(c, &dto) {
+		return
+	}
+
+	ctx := httputil.GRPCAuth(c)
+
+	h.Log.Debug("category handler::createMany call gRPC /CategoryClient/CreateCategory")
+	results := handlers.CreateMany(ctx, c, h.BaseBoard, dto.items(), func(item CreateCategoryDTO) string {
+		return item.BoardID
+	}, func(ctx context.Context, item CreateCategoryDTO, id string) error {
+		_, err := h.CategoryService.CreateCategory(ctx, item.toCreate(id))
+		return err
+	})
+
+	h.reindex(ctx, handlers.CreatedIDs(results)...)
+
+	httputil.MultiStatus(c, results)
+}
+
+// @Summary Delete Many Categories
+// @Description Categories are moved to the trash, DELETE is checked per category.
+// @Tags Categories
+// @ModuleID deleteManyCategories
+// @Accept json
+// @Produce json
+// @Param payload body categories.DeleteManyCategoriesDTO true "Categories IDs"
+// @Success 207 {object} httputil.BatchResponse
+// @Failure 400,401,422,500 {object} httputil.APIError
+// @Router /categories [delete]
+// @Security BearerAuth
+func (h *Handler) deleteMany(c *gin.Context) {
+	h.Log.Debug("category handler::deleteMany bind")
+	var dto DeleteManyCategoriesDTO
+	if !binding.BindBodyAndValidate(c, &dto) {
+		return
+	}
+
+	ctx := httputil.GRPCAuth(c)
+	userID := httputil.GetUserID(c)
+
+	h.Log.Debug("category handler::deleteMany call gRPC /CategoryClient/GetCategories")
+	response, err := h.CategoryService.GetCategories(ctx, dto.toRead())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	results := handlers.DeleteMany(ctx, c, h.BaseBoard, dto.IDs, response.GetCategories(),
+		(*v1Category.CategoriesResponse_Category).GetCategoryId,
+		(*v1Category.CategoriesResponse_Category).GetBoardId,
+		ToChild,
+		func(ctx context.Context, category *v1Category.CategoriesResponse_Category) error {
+			return h.moveToTrash(ctx, userID, category)
+		})
+
+	httputil.MultiStatus(c, results)
+}
+
+// @Summary Order Categories
+// @Description Position categories of the board in the order of the list, which must contain all of them.
+// @Description A list not matching the current categories of the board is rejected as stale.
+// @Tags Categories
+// @ModuleID orderCategories
+// @Accept json
+// @Param board_id path string true "Board ID" format(uuid)
+// @Param payload body categories.OrderCategoriesDTO true "Ordered categories IDs"
+// @Success 204
+// @Failure 400,401,403,404,409,422,500 {object} httputil.APIError
+// @Router /boards/{board_id}/categories/order [post]
+// @Security BearerAuth
+func (h *Handler) order(c *gin.Context) {
+	h.Log.Debug("category handler::order bind")
+	var dto OrderCategoriesDTO
+	if !binding.BindBody(c, &dto) || !binding.BindUriAndValidate(c, &dto) {
+		return
+	}
+
+	ctx := httputil.GRPCAuth(c)
+
+	if !h.IsGranted(ctx, c, dto.BoardID, "UPDATE") {
+		return
+	}
+
+	h.Log.Debug("category handler::order call gRPC /CategoryClient/GetCategories")
+	categories, err := clientutil.GetAllCategories(ctx, h.CategoryService, &v1Category.CategoriesRequest{
+		BoardIds: []string{dto.BoardID},
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	req, err := dto.toUpdateMany(categories)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if len(req.GetCategories()) > 0 {
+		h.Log.Debug("category handler::order call gRPC /CategoryClient/UpdateManyCategories")
+		if _, err = h.CategoryService.UpdateManyCategories(ctx, req); err != nil {
+			_ = c.Error(err)
+			return
+		}
+	}
+
 	httputil.NoContent(c)
 }
 
@@ -184,7 +353,7 @@ func (h *Handler) read(c *gin.Context) {
 		return
 	}
 
-	ctx := context.TODO()
+	ctx := httputil.GRPCAuth(c)
 
 	h.Log.Debug("category handler::read call gRPC /CategoryClient/GetCategories")
 	category, err := clientutil.GetCategory(ctx, h.CategoryService, dto.CategoryID)
@@ -193,7 +362,7 @@ func (h *Handler) read(c *gin.Context) {
 		return
 	}
 
-	if !h.IsGranted(ctx, c, category.GetBoardId(), "READ") {
+	if !h.IsGranted(ctx, c, category.GetBoardId(), "READ", ToChild(category)) {
 		return
 	}
 
@@ -217,7 +386,7 @@ func (h *Handler) update(c *gin.Context) {
 		return
 	}
 
-	ctx := context.TODO()
+	ctx := httputil.GRPCAuth(c)
 
 	h.Log.Debug("category handler::update call gRPC /CategoryClient/GetCategories")
 	category, err := clientutil.GetCategory(ctx, h.CategoryService, dto.CategoryID)
@@ -226,7 +395,7 @@ func (h *Handler) update(c *gin.Context) {
 		return
 	}
 
-	if !h.IsGranted(ctx, c, category.GetBoardId(), "UPDATE") {
+	if !h.IsGranted(ctx, c, category.GetBoardId(), "UPDATE", ToChild(category)) {
 		return
 	}
 
@@ -240,10 +409,13 @@ func (h *Handler) update(c *gin.Context) {
 		return
 	}
 
+	h.reindex(ctx, dto.CategoryID)
+
 	httputil.NoContent(c)
 }
 
 // @Summary Delete Category
+// @Description The category is moved to the trash.
 // @Tags Categories
 // @ModuleID deleteCategory
 // @Param category_id path string true "Category ID" format(uuid)
@@ -258,7 +430,7 @@ func (h *Handler) delete(c *gin.Context) {
 		return
 	}
 
-	ctx := context.TODO()
+	ctx := httputil.GRPCAuth(c)
 
 	h.Log.Debug("category handler::delete call gRPC /CategoryClient/GetCategories")
 	category, err := clientutil.GetCategory(ctx, h.CategoryService, dto.CategoryID)
@@ -267,15 +439,60 @@ func (h *Handler) delete(c *gin.Context) {
 		return
 	}
 
-	if !h.IsGranted(ctx, c, category.GetBoardId(), "DELETE") {
+	if !h.IsGranted(ctx, c, category.GetBoardId(), "DELETE", ToChild(category)) {
 		return
 	}
 
-	h.Log.Debug("category handler::delete call gRPC /CategoryClient/DeleteCategory")
-	if _, err = h.CategoryService.DeleteCategory(ctx, dto.toDelete()); err != nil {
+	if err = h.moveToTrash(ctx, httputil.GetUserID(c), category); err != nil {
 		_ = c.Error(err)
 		return
 	}
 
 	httputil.NoContent(c)
 }
+
+// moveToTrash deletes the category, keeping it in the trash for restore.
+func (h *Handler) moveToTrash(ctx context.Context, userID string, category *v1Category.CategoriesResponse_Category) error {
+	saga := handlers.NewSaga(h.Log)
+	entry := toTrash(category, userID)
+
+	h.Log.Debug("category handler::moveToTrash put entry")
+	if err := saga.Do(ctx, "PutTrash", func(ctx context.Context) error {
+		return h.Trash.Put(ctx, entry, toRecreate(category))
+	}, func(ctx context.Context) error {
+		return h.Trash.Remove(ctx, entry)
+	}); err != nil {
+		return err
+	}
+
+	h.Log.Debug("category handler::moveToTrash call gRPC /CategoryClient/DeleteCategory")
+	if err := saga.Do(ctx, "DeleteCategory", func(ctx context.Context) error {
+		_, err := h.CategoryService.DeleteCategory(ctx, &v1Category.DeleteCategoryRequest{CategoryId: category.GetCategoryId()})
+		return err
+	}, nil); err != nil {
+		return err
+	}
+
+	h.Search.Remove(ctx, category.GetCategoryId())
+	return nil
+}
+
+// reindex feeds the search index with the current state of the categories.
+func (h *Handler) reindex(ctx context.Context, ids ...string) {
+	if len(ids) == 0 {
+		return
+	}
+
+	h.Log.Debug("category handler::reindex call gRPC /CategoryClient/GetCategories")
+	response, err := h.CategoryService.GetCategories(ctx, &v1Category.CategoriesRequest{
+		PageIndex:   0,
+		PageSize:    uint32(len(ids)),
+		CategoryIds: ids,
+	})
+	if err != nil {
+		h.Log.Error("category handler::reindex", zap.Error(err))
+		return
+	}
+
+	h.Search.Add(ctx, slice.Map(response.GetCategories(), search.CategoryDocument)...)
+}
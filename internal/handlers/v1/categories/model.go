@@ -1,7 +1,10 @@
 package categories
 
 import (
+	v1Authz "github.com/go-funcards/funapi/internal/client/authz_service/v1"
 	"github.com/go-funcards/funapi/internal/gin/httputil"
+	"github.com/go-funcards/funapi/internal/handlers"
+	"github.com/go-funcards/funapi/internal/trash"
 	"github.com/go-funcards/funapi/proto/category_service/v1"
 	"github.com/go-funcards/slice"
 	"time"
@@ -23,14 +26,19 @@ type PageResponse struct {
 
 type PageRequest struct {
 	httputil.PageRequest
-	BoardID string `json:"-" form:"board_id" validate:"required,uuid4"`
+	BoardIDs    []string      `json:"-" form:"board_id" validate:"required,min=1,max=100,unique,dive,uuid4"`
+	CategoryIDs []string      `json:"-" form:"category_id" validate:"omitempty,max=100,unique,dive,uuid4"`
+	OwnerIDs    []string      `json:"-" form:"owner_id" validate:"omitempty,max=100,unique,dive,uuid4"`
+	Sort        httputil.Sort `json:"-" form:"sort" validate:"omitempty,max=200,sort=created_at name position"`
 }
 
-func (dto PageRequest) toRead() *v1.CategoriesRequest {
+func (dto PageRequest) toRead(index uint64, size uint32) *v1.CategoriesRequest {
 	return &v1.CategoriesRequest{
-		PageIndex: dto.Index,
-		PageSize:  dto.Size,
-		BoardIds:  []string{dto.BoardID},
+		PageIndex:   index,
+		PageSize:    size,
+		BoardIds:    dto.BoardIDs,
+		CategoryIds: dto.CategoryIDs,
+		OwnerIds:    dto.OwnerIDs,
 	}
 }
 
@@ -51,6 +59,19 @@ func (dto CreateCategoryDTO) toCreate(id string) *v1.CreateCategoryRequest {
 	}
 }
 
+type CreateManyCategoriesDTO struct {
+	OwnerID string              `json:"-" ctx:"user_id" validate:"required,uuid4"`
+	Data    []CreateCategoryDTO `json:"data" validate:"required,min=1,max=1000"`
+}
+
+// items returns the items owned by the caller, they are validated one by one.
+func (dto CreateManyCategoriesDTO) items() []CreateCategoryDTO {
+	return slice.Map(dto.Data, func(item CreateCategoryDTO) CreateCategoryDTO {
+		item.OwnerID = dto.OwnerID
+		return item
+	})
+}
+
 type UpdateCategoryDTO struct {
 	CategoryID string `json:"category_id" uri:"category_id" validate:"required,uuid4" format:"uuid"`
 	BoardID    string `json:"board_id" validate:"omitempty,uuid4" format:"uuid"`
@@ -79,17 +100,82 @@ func (dto UpdateManyCategoriesDTO) toUpdateMany() *v1.UpdateManyCategoriesReques
 	}
 }
 
+type OrderCategoriesDTO struct {
+	BoardID string   `json:"-" uri:"board_id" validate:"required,uuid4"`
+	IDs     []string `json:"ids" validate:"required,min=1,unique,dive,uuid4"`
+}
+
+// toUpdateMany positions categories in the order of the request, categories already
+// at their position are not updated. The request must list all categories of the board.
+func (dto OrderCategoriesDTO) toUpdateMany(categories []*v1.CategoriesResponse_Category) (*v1.UpdateManyCategoriesRequest, error) {
+	current := make(map[string]int32, len(categories))
+	for _, category := range categories {
+		current[category.GetCategoryId()] = category.GetPosition()
+	}
+	if len(current) != len(dto.IDs) {
+		return nil, httputil.ErrConflict
+	}
+
+	req := &v1.UpdateManyCategoriesRequest{}
+	for i, position := range handlers.Positions(len(dto.IDs)) {
+		prev, ok := current[dto.IDs[i]]
+		if !ok {
+			// categories of the board changed since the client read them
+			return nil, httputil.ErrConflict
+		}
+		if prev != position {
+			req.Categories = append(req.Categories, &v1.UpdateCategoryRequest{
+				CategoryId: dto.IDs[i],
+				Position:   position,
+			})
+		}
+	}
+	return req, nil
+}
+
 type ReadCategoryDTO struct {
 	CategoryID string `json:"-" uri:"category_id" validate:"required,uuid4"`
 }
 
+type DeleteManyCategoriesDTO struct {
+	IDs []string `json:"ids" validate:"required,min=1,max=1000,unique,dive,uuid4"`
+}
+
+func (dto DeleteManyCategoriesDTO) toRead() *v1.CategoriesRequest {
+	return &v1.CategoriesRequest{
+		PageIndex:   0,
+		PageSize:    uint32(len(dto.IDs)),
+		CategoryIds: dto.IDs,
+	}
+}
+
 type DeleteCategoryDTO struct {
 	CategoryID string `json:"-" uri:"category_id" validate:"required,uuid4"`
 }
 
-func (dto DeleteCategoryDTO) toDelete() *v1.DeleteCategoryRequest {
-	return &v1.DeleteCategoryRequest{
-		CategoryId: dto.CategoryID,
+// ToChild returns the authz object of the category, as a child of its board.
+func ToChild(category *v1.CategoriesResponse_Category) v1Authz.Child {
+	return v1Authz.Child{ID: category.GetCategoryId(), Owner: category.GetOwnerId()}
+}
+
+func toTrash(category *v1.CategoriesResponse_Category, userID string) trash.Entry {
+	return trash.Entry{
+		ID:        category.GetCategoryId(),
+		Type:      trash.TypeCategory,
+		BoardID:   category.GetBoardId(),
+		Name:      category.GetName(),
+		DeletedBy: userID,
+	}
+}
+
+// toRecreate restores a deleted category.
+func toRecreate(category *v1.CategoriesResponse_Category) *v1.CreateCategoryRequest {
+	return &v1.CreateCategoryRequest{
+		CategoryId: category.GetCategoryId(),
+		OwnerId:    category.GetOwnerId(),
+		BoardId:    category.GetBoardId(),
+		Name:       category.GetName(),
+		Position:   category.GetPosition(),
 	}
 }
 
@@ -97,13 +183,28 @@ func PageReq() PageRequest {
 	return PageRequest{PageRequest: httputil.PageRequest{Size: 1}}
 }
 
-func PageResp(response *v1.CategoriesResponse, req PageRequest) PageResponse {
+func PageResp(items []*v1.CategoriesResponse_Category, page httputil.PageResponse, req PageRequest) PageResponse {
+	data := slice.Map(items, func(b *v1.CategoriesResponse_Category) Category {
+		return CreateCategory(b)
+	})
+	httputil.SortSlice(data, req.Sort, compareCategories)
+
 	return PageResponse{
-		PageResponse: req.ToPageResponse(response.GetTotal()),
-		Data: slice.Map(response.GetCategories(), func(b *v1.CategoriesResponse_Category) Category {
-			return CreateCategory(b)
-		}),
+		PageResponse: page,
+		Data:         data,
+	}
+}
+
+func compareCategories(a, b Category, field string) int {
+	switch field {
+	case "name":
+		return httputil.CompareStrings(a.Name, b.Name)
+	case "position":
+		return httputil.CompareInts(a.Position, b.Position)
+	case "created_at":
+		return httputil.CompareTimes(a.CreatedAt, b.CreatedAt)
 	}
+	return 0
 }
 
 func CreateCategory(response *v1.CategoriesResponse_Category) Category {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/go-funcards/funapi/internal/gin/httputil"
 	v1Board "github.com/go-funcards/funapi/proto/board_service/v1"
+	"google.golang.org/protobuf/proto"
 )
 
 func BoardsRequest(id string) *v1Board.BoardsRequest {
@@ -24,3 +25,21 @@ func GetBoard(ctx context.Context, client v1Board.BoardClient, id string) (*v1Bo
 	}
 	return response.GetBoards()[0], nil
 }
+
+// GetAllBoards pages through all boards matching the request.
+func GetAllBoards(ctx context.Context, client v1Board.BoardClient, in *v1Board.BoardsRequest) ([]*v1Board.BoardsResponse_Board, error) {
+	req := proto.Clone(in).(*v1Board.BoardsRequest)
+	req.PageSize = AllPageSize
+
+	var boards []*v1Board.BoardsResponse_Board
+	for req.PageIndex = 0; ; req.PageIndex++ {
+		response, err := client.GetBoards(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		boards = append(boards, response.GetBoards()...)
+		if len(response.GetBoards()) < AllPageSize || uint64(len(boards)) >= response.GetTotal() {
+			return boards, nil
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/go-funcards/funapi/internal/gin/httputil"
 	v1Card "github.com/go-funcards/funapi/proto/card_service/v1"
+	"google.golang.org/protobuf/proto"
 )
 
 func CardsRequest(id string) *v1Card.CardsRequest {
@@ -24,3 +25,24 @@ func GetCard(ctx context.Context, client v1Card.CardClient, id string) (*v1Card.
 	}
 	return response.GetCards()[0], nil
 }
+
+// AllPageSize is the page size used to fetch all results of a request.
+const AllPageSize = 1000
+
+// GetAllCards pages through all cards matching the request.
+func GetAllCards(ctx context.Context, client v1Card.CardClient, in *v1Card.CardsRequest) ([]*v1Card.CardsResponse_Card, error) {
+	req := proto.Clone(in).(*v1Card.CardsRequest)
+	req.PageSize = AllPageSize
+
+	var cards []*v1Card.CardsResponse_Card
+	for req.PageIndex = 0; ; req.PageIndex++ {
+		response, err := client.GetCards(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, response.GetCards()...)
+		if len(response.GetCards()) < AllPageSize || uint64(len(cards)) >= response.GetTotal() {
+			return cards, nil
+		}
+	}
+}
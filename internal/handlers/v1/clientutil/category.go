@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/go-funcards/funapi/internal/gin/httputil"
 	v1Category "github.com/go-funcards/funapi/proto/category_service/v1"
+	"google.golang.org/protobuf/proto"
 )
 
 func CategoriesRequest(id string) *v1Category.CategoriesRequest {
@@ -24,3 +25,21 @@ func GetCategory(ctx context.Context, client v1Category.CategoryClient, id strin
 	}
 	return response.GetCategories()[0], nil
 }
+
+// GetAllCategories pages through all categories matching the request.
+func GetAllCategories(ctx context.Context, client v1Category.CategoryClient, in *v1Category.CategoriesRequest) ([]*v1Category.CategoriesResponse_Category, error) {
+	req := proto.Clone(in).(*v1Category.CategoriesRequest)
+	req.PageSize = AllPageSize
+
+	var categories []*v1Category.CategoriesResponse_Category
+	for req.PageIndex = 0; ; req.PageIndex++ {
+		response, err := client.GetCategories(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, response.GetCategories()...)
+		if len(response.GetCategories()) < AllPageSize || uint64(len(categories)) >= response.GetTotal() {
+			return categories, nil
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/go-funcards/funapi/internal/gin/httputil"
 	v1Tag "github.com/go-funcards/funapi/proto/tag_service/v1"
+	"google.golang.org/protobuf/proto"
 )
 
 func TagsRequest(id string) *v1Tag.TagsRequest {
@@ -24,3 +25,21 @@ func GetTag(ctx context.Context, client v1Tag.TagClient, id string) (*v1Tag.Tags
 	}
 	return response.GetTags()[0], nil
 }
+
+// GetAllTags pages through all tags matching the request.
+func GetAllTags(ctx context.Context, client v1Tag.TagClient, in *v1Tag.TagsRequest) ([]*v1Tag.TagsResponse_Tag, error) {
+	req := proto.Clone(in).(*v1Tag.TagsRequest)
+	req.PageSize = AllPageSize
+
+	var tags []*v1Tag.TagsResponse_Tag
+	for req.PageIndex = 0; ; req.PageIndex++ {
+		response, err := client.GetTags(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, response.GetTags()...)
+		if len(response.GetTags()) < AllPageSize || uint64(len(tags)) >= response.GetTotal() {
+			return tags, nil
+		}
+	}
+}
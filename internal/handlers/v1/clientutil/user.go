@@ -24,3 +24,18 @@ func GetUser(ctx context.Context, client v1User.UserClient, id string) (*v1User.
 	}
 	return response.GetUsers()[0], nil
 }
+
+func GetUserByEmail(ctx context.Context, client v1User.UserClient, email string) (*v1User.UserResponse, error) {
+	response, err := client.GetUsers(ctx, &v1User.UsersRequest{
+		PageIndex: 0,
+		PageSize:  1,
+		Emails:    []string{email},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(response.GetUsers()) != 1 {
+		return nil, httputil.ErrNotFound
+	}
+	return response.GetUsers()[0], nil
+}
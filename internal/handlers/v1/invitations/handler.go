@@ -0,0 +1,293 @@
+package invitations
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/go-funcards/funapi/internal/gin/binding"
+	"github.com/go-funcards/funapi/internal/gin/httputil"
+	"github.com/go-funcards/funapi/internal/gin/middleware"
+	"github.com/go-funcards/funapi/internal/handlers"
+	"github.com/go-funcards/funapi/internal/handlers/v1/clientutil"
+	"github.com/go-funcards/funapi/internal/handlers/v1/members"
+	"github.com/go-funcards/funapi/internal/invitation"
+	"github.com/go-funcards/funapi/internal/notify"
+	"github.com/go-funcards/funapi/internal/tokenstore"
+	v1User "github.com/go-funcards/funapi/proto/user_service/v1"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+var _ handlers.Handler = (*Handler)(nil)
+
+type Handler struct {
+	*handlers.BaseBoard
+	// IsGrantedToFn checks that the inviter may still invite to the board when the invitation is accepted
+	IsGrantedToFn httputil.IsGrantedToFn
+	Members       *members.Handler
+	UserService   v1User.UserClient
+	Invitations   *invitation.Store
+	Verifications *tokenstore.Verifications
+	Notifier      notify.Notifier
+	// URL of the client page listing invitations of the user, sent in the invitation email
+	URL string
+	Log *zap.Logger
+}
+
+func (h *Handler) Register(rg *gin.RouterGroup) {
+	rg.POST("/boards/:board_id/invitations", h.create)
+	rg.GET("/users/me/invitations", h.list)
+
+	g := rg.Group("/invitations/:invitation_id")
+	{
+		g.POST("/accept", h.accept)
+		g.POST("/decline", h.decline)
+	}
+}
+
+// @Summary Invite To Board
+// @Tags Boards
+// @Description Invite a user by email to become a member of the board with the roles. A pending invitation
+// @Description of the board for the same email is replaced. The invitation expires unless accepted or declined.
+// @ModuleID createBoardInvitation
+// @Accept json
+// @Produce json
+// @Param board_id path string true "Board ID" format(uuid)
+// @Param payload body invitations.CreateInvitationDTO true "Invitation data"
+// @Success 201 {object} invitations.Invitation
+// @Failure 400,401,403,404,409,422,500 {object} httputil.APIError
+// @Router /boards/{board_id}/invitations [post]
+// @Security BearerAuth
+func (h *Handler) create(c *gin.Context) {
+	h.Log.Debug("invitation handler::create bind")
+	var dto CreateInvitationDTO
+	if !binding.BindCtx(c, &dto) || !binding.BindUri(c, &dto) || !binding.BindBodyAndValidate(c, &dto) {
+		return
+	}
+
+	ctx := httputil.GRPCAuth(c)
+
+	board, ok := h.GrantedBoard(ctx, c, dto.BoardID, "SAVE_MEMBER")
+	if !ok {
+		return
+	}
+
+	h.Log.Debug("invitation handler::create call gRPC /UserClient/GetUsers")
+	user, err := clientutil.GetUserByEmail(ctx, h.UserService, invitation.NormalizeEmail(dto.Email))
+	if err != nil && !errors.Is(err, httputil.ErrNotFound) {
+		_ = c.Error(err)
+		return
+	}
+	if err == nil && isMember(board, user.GetUserId()) {
+		_ = c.Error(httputil.ErrConflict)
+		return
+	}
+
+	inv, err := h.Invitations.Put(ctx, dto.toInvitation(uuid.NewString(), board))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err = h.Notifier.Notify(ctx, invitationMessage(inv, h.URL)); err != nil {
+		h.Log.Error("invitation handler::create notify", zap.Error(err))
+	}
+
+	c.JSON(http.StatusCreated, CreateInvitation(inv))
+}
+
+// @Summary Invitations
+// @Tags Users
+// @Description Return pending invitations for the verified email of authenticated user
+// @ModuleID listInvitations
+// @Produce json
+// @Param page_index query int false "Page Index" minimum(0)
+// @Param page_size query int false "Page Size" minimum(1) maximum(1000)
+// @Param cursor query string false "Cursor of the next page, takes precedence over page_index"
+// @Success 200 {object} invitations.PageResponse
+// @Failure 400,401,403,500 {object} httputil.APIError
+// @Router /users/me/invitations [get]
+// @Security BearerAuth
+func (h *Handler) list(c *gin.Context) {
+	h.Log.Debug("invitation handler::list bind")
+	req := PageReq()
+	if !binding.BindCtx(c, &req) || !binding.BindQueryAndValidate(c, &req) {
+		return
+	}
+
+	ctx := httputil.GRPCAuth(c)
+
+	email, ok := h.email(ctx, c, req.UserID)
+	if !ok {
+		return
+	}
+
+	invitations, page, err := httputil.Paginate(c, req.PageRequest, func(index uint64, size uint32) ([]invitation.Invitation, uint64, error) {
+		return h.Invitations.List(ctx, email, index, size)
+	}, func(inv invitation.Invitation) string {
+		return inv.ID
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	httputil.NoCache(c)
+	c.JSON(http.StatusOK, PageResp(invitations, page))
+}
+
+// @Summary Accept Invitation
+// @Tags Users
+// @Description Become a member of the board with the roles of the invitation, members of the board get 409.
+// @Description Invitations of users who may not invite to the board anymore are not found.
+// @ModuleID acceptInvitation
+// @Param invitation_id path string true "Invitation ID" format(uuid)
+// @Success 204
+// @Failure 400,401,403,404,409,500 {object} httputil.APIError
+// @Router /invitations/{invitation_id}/accept [post]
+// @Security BearerAuth
+func (h *Handler) accept(c *gin.Context) {
+	h.Log.Debug("invitation handler::accept bind")
+	var dto InvitationDTO
+	if !binding.BindCtx(c, &dto) || !binding.BindUriAndValidate(c, &dto) {
+		return
+	}
+
+	ctx := httputil.GRPCAuth(c)
+
+	inv, ok := h.pending(ctx, c, dto)
+	if !ok {
+		return
+	}
+
+	h.Log.Debug("invitation handler::accept call gRPC /BoardClient/GetBoards")
+	board, err := h.GetBoard(ctx, inv.BoardID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// roles of an existing member must not be replaced by the invitation
+	if isMember(board, dto.UserID) {
+		_ = c.Error(httputil.ErrConflict)
+		return
+	}
+
+	// the inviter may have lost the permission since, e.g. when removed from the board
+	if err = h.IsGrantedToFn(ctx, inv.InvitedBy, board.GetOwnerId(), board.GetBoardId(), "SAVE_MEMBER"); err != nil {
+		if errors.Is(err, httputil.ErrForbidden) {
+			if !h.claim(ctx, c, inv) {
+				return
+			}
+			err = httputil.ErrNotFound
+		}
+		_ = c.Error(err)
+		return
+	}
+
+	if !h.claim(ctx, c, inv) {
+		return
+	}
+
+	if err = h.Members.SaveMember(ctx, board, dto.toSaveMember(inv)); err != nil {
+		if err := h.Invitations.Unclaim(ctx, inv); err != nil {
+			h.Log.Error("invitation handler::accept unclaim", zap.String("invitation_id", inv.ID), zap.Error(err))
+		}
+		_ = c.Error(err)
+		return
+	}
+
+	httputil.NoContent(c)
+}
+
+// @Summary Decline Invitation
+// @Tags Users
+// @ModuleID declineInvitation
+// @Param invitation_id path string true "Invitation ID" format(uuid)
+// @Success 204
+// @Failure 400,401,403,404,500 {object} httputil.APIError
+// @Router /invitations/{invitation_id}/decline [post]
+// @Security BearerAuth
+func (h *Handler) decline(c *gin.Context) {
+	h.Log.Debug("invitation handler::decline bind")
+	var dto InvitationDTO
+	if !binding.BindCtx(c, &dto) || !binding.BindUriAndValidate(c, &dto) {
+		return
+	}
+
+	ctx := httputil.GRPCAuth(c)
+
+	inv, ok := h.pending(ctx, c, dto)
+	if !ok || !h.claim(ctx, c, inv) {
+		return
+	}
+
+	httputil.NoContent(c)
+}
+
+// email returns the verified email of the user. Invitations are matched by it regardless of the
+// restriction of unverified users, the current email of the user may be changed and unverified.
+func (h *Handler) email(ctx context.Context, c *gin.Context, userID string) (string, bool) {
+	verified, err := h.Verifications.IsVerified(ctx, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return "", false
+	}
+	if !verified {
+		_ = c.Error(middleware.ErrEmailNotVerified)
+		return "", false
+	}
+
+	email, err := h.Verifications.VerifiedEmail(ctx, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return "", false
+	}
+	if len(email) > 0 {
+		return invitation.NormalizeEmail(email), true
+	}
+
+	// verified before addresses were recorded, a changed email is unverified since
+	h.Log.Debug("invitation handler::email call gRPC /UserClient/GetUsers")
+	user, err := clientutil.GetUser(ctx, h.UserService, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return "", false
+	}
+	return invitation.NormalizeEmail(user.GetEmail()), true
+}
+
+// pending returns the invitation for the email of the user, invitations for other emails are not found.
+func (h *Handler) pending(ctx context.Context, c *gin.Context, dto InvitationDTO) (invitation.Invitation, bool) {
+	email, ok := h.email(ctx, c, dto.UserID)
+	if !ok {
+		return invitation.Invitation{}, false
+	}
+
+	inv, err := h.Invitations.Get(ctx, dto.InvitationID)
+	if err == nil && inv.Email != email {
+		err = invitation.ErrNotFound
+	}
+	if err != nil {
+		if errors.Is(err, invitation.ErrNotFound) {
+			err = httputil.ErrNotFound
+		}
+		_ = c.Error(err)
+		return invitation.Invitation{}, false
+	}
+	return inv, true
+}
+
+// claim removes the invitation, it is not found when a concurrent request claimed it first.
+func (h *Handler) claim(ctx context.Context, c *gin.Context, inv invitation.Invitation) bool {
+	claimed, err := h.Invitations.Claim(ctx, inv)
+	if err == nil && !claimed {
+		err = httputil.ErrNotFound
+	}
+	if err != nil {
+		_ = c.Error(err)
+		return false
+	}
+	return true
+}
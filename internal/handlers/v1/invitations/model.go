@@ -0,0 +1,104 @@
+package invitations
+
+import (
+	"fmt"
+	"github.com/go-funcards/funapi/internal/gin/httputil"
+	"github.com/go-funcards/funapi/internal/handlers/v1/members"
+	"github.com/go-funcards/funapi/internal/invitation"
+	"github.com/go-funcards/funapi/internal/notify"
+	v1Board "github.com/go-funcards/funapi/proto/board_service/v1"
+	"github.com/go-funcards/slice"
+	"time"
+)
+
+type Invitation struct {
+	ID        string    `json:"id"`
+	BoardID   string    `json:"board_id"`
+	BoardName string    `json:"board_name"`
+	Email     string    `json:"email"`
+	Roles     []string  `json:"roles"`
+	InvitedBy string    `json:"invited_by"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type CreateInvitationDTO struct {
+	BoardID   string   `json:"-" uri:"board_id" validate:"required,uuid4"`
+	InvitedBy string   `json:"-" ctx:"user_id" validate:"required,uuid4"`
+	Email     string   `json:"email" validate:"required,email,min=3,max=255"`
+	Roles     []string `json:"roles" validate:"required,dive,min=1,max=50"`
+}
+
+func (dto CreateInvitationDTO) toInvitation(id string, board *v1Board.BoardsResponse_Board) invitation.Invitation {
+	return invitation.Invitation{
+		ID:        id,
+		BoardID:   board.GetBoardId(),
+		BoardName: board.GetName(),
+		Email:     dto.Email,
+		Roles:     dto.Roles,
+		InvitedBy: dto.InvitedBy,
+	}
+}
+
+type PageRequest struct {
+	httputil.PageRequest
+	UserID string `json:"-" ctx:"user_id" validate:"required,uuid4"`
+}
+
+type PageResponse struct {
+	httputil.PageResponse
+	Data []Invitation `json:"data"`
+}
+
+type InvitationDTO struct {
+	UserID       string `json:"-" ctx:"user_id" validate:"required,uuid4"`
+	InvitationID string `json:"-" uri:"invitation_id" validate:"required,uuid4"`
+}
+
+func (dto InvitationDTO) toSaveMember(inv invitation.Invitation) members.SaveMemberDTO {
+	return members.SaveMemberDTO{
+		BoardID:  inv.BoardID,
+		MemberID: dto.UserID,
+		Roles:    inv.Roles,
+	}
+}
+
+func isMember(board *v1Board.BoardsResponse_Board, userID string) bool {
+	return board.GetOwnerId() == userID || slice.Contains(slice.Map(board.GetMembers(), (*v1Board.BoardsResponse_Board_Member).GetMemberId), userID)
+}
+
+func invitationMessage(inv invitation.Invitation, listURL string) notify.Message {
+	link := "Sign in to FunCards to accept or decline it."
+	if len(listURL) > 0 {
+		link = fmt.Sprintf("Accept or decline it at:\n\n%s", listURL)
+	}
+	return notify.Message{
+		To:      inv.Email,
+		Subject: fmt.Sprintf("You are invited to the FunCards board %q", inv.BoardName),
+		Body:    fmt.Sprintf("You are invited to become a member of the board %q. %s\n\nThe invitation expires on %s. If you do not have a FunCards account yet, sign up with this email address first.", inv.BoardName, link, inv.ExpiresAt.Format(time.RFC1123)),
+	}
+}
+
+func PageReq() PageRequest {
+	return PageRequest{PageRequest: httputil.PageRequest{Size: 1}}
+}
+
+func PageResp(invitations []invitation.Invitation, page httputil.PageResponse) PageResponse {
+	return PageResponse{
+		PageResponse: page,
+		Data:         slice.Map(invitations, CreateInvitation),
+	}
+}
+
+func CreateInvitation(inv invitation.Invitation) Invitation {
+	return Invitation{
+		ID:        inv.ID,
+		BoardID:   inv.BoardID,
+		BoardName: inv.BoardName,
+		Email:     inv.Email,
+		Roles:     slice.Copy(inv.Roles),
+		InvitedBy: inv.InvitedBy,
+		CreatedAt: inv.CreatedAt,
+		ExpiresAt: inv.ExpiresAt,
+	}
+}
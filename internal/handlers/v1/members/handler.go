@@ -7,7 +7,10 @@ import (
 	"github.com/go-funcards/funapi/internal/gin/httputil"
 	"github.com/go-funcards/funapi/internal/handlers"
 	v1Authz "github.com/go-funcards/funapi/proto/authz_service/v1"
+	v1Board "github.com/go-funcards/funapi/proto/board_service/v1"
+	v1User "github.com/go-funcards/funapi/proto/user_service/v1"
 	"go.uber.org/zap"
+	"net/http"
 )
 
 var _ handlers.Handler = (*Handler)(nil)
@@ -15,12 +18,15 @@ var _ handlers.Handler = (*Handler)(nil)
 type Handler struct {
 	*handlers.BaseBoard
 	SubjectService v1Authz.SubjectClient
+	UserService    v1User.UserClient
 	Log            *zap.Logger
 }
 
 func (h *Handler) Register(rg *gin.RouterGroup) {
 	g := rg.Group("/members")
 	{
+		g.GET("", h.list)
+
 		m := g.Group("/:member_id")
 		{
 			m.PUT("", h.save)
@@ -29,6 +35,60 @@ func (h *Handler) Register(rg *gin.RouterGroup) {
 	}
 }
 
+// @Summary Board Members
+// @Tags Boards
+// @Description Return members of the board with their names and roles, the owner of the board first with the OWNER role.
+// @Description Emails are shown to the member and to users allowed to save members of the board.
+// @ModuleID listBoardMembers
+// @Produce json
+// @Param board_id path string true "Board ID" format(uuid)
+// @Param page_index query int false "Page Index" minimum(0)
+// @Param page_size query int false "Page Size" minimum(1) maximum(1000)
+// @Param cursor query string false "Cursor of the next page, takes precedence over page_index"
+// @Success 200 {object} members.PageResponse
+// @Failure 400,401,403,404,500 {object} httputil.APIError
+// @Router /boards/{board_id}/members [get]
+// @Security BearerAuth
+func (h *Handler) list(c *gin.Context) {
+	h.Log.Debug("member handler::list bind")
+	req := PageReq()
+	if !binding.BindCtx(c, &req) || !binding.BindUri(c, &req) || !binding.BindQueryAndValidate(c, &req) {
+		return
+	}
+
+	ctx := httputil.GRPCAuth(c)
+
+	board, ok := h.GrantedBoard(ctx, c, req.BoardID, "READ")
+	if !ok {
+		return
+	}
+
+	members, page, err := httputil.Paginate(c, req.PageRequest, func(index uint64, size uint32) ([]*v1Board.BoardsResponse_Board_Member, uint64, error) {
+		items, total := membersPage(board, index, size)
+		return items, total, nil
+	}, (*v1Board.BoardsResponse_Board_Member).GetMemberId)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var users []*v1User.UserResponse
+	if len(members) > 0 {
+		h.Log.Debug("member handler::list call gRPC /UserClient/GetUsers")
+		response, err := h.UserService.GetUsers(ctx, toUsers(members))
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		users = response.GetUsers()
+	}
+
+	showEmails := h.IsGrantedFn(ctx, c, board.GetOwnerId(), board.GetBoardId(), "SAVE_MEMBER") == nil
+
+	httputil.NoCache(c)
+	c.JSON(http.StatusOK, PageResp(members, users, page, req.UserID, showEmails))
+}
+
 // @Summary Save Board Member
 // @Tags Boards
 // @ModuleID saveBoardMember
@@ -41,7 +101,7 @@ func (h *Handler) Register(rg *gin.RouterGroup) {
 // @Router /boards/{board_id}/members/{member_id} [put]
 // @Security BearerAuth
 func (h *Handler) save(c *gin.Context) {
-	ctx := context.TODO()
+	ctx := httputil.GRPCAuth(c)
 
 	h.Log.Debug("member handler::add bind")
 	var dto SaveMemberDTO
@@ -49,23 +109,39 @@ func (h *Handler) save(c *gin.Context) {
 		return
 	}
 
-	if !h.IsGranted(ctx, c, dto.BoardID, "SAVE_MEMBER") {
+	board, ok := h.GrantedBoard(ctx, c, dto.BoardID, "SAVE_MEMBER")
+	if !ok {
 		return
 	}
 
-	h.Log.Debug("member handler::add call gRPC /SubjectClient/SaveSub")
-	if _, err := h.SubjectService.SaveSub(ctx, dto.toSaveSub()); err != nil {
+	if err := h.SaveMember(ctx, board, dto); err != nil {
 		_ = c.Error(err)
 		return
 	}
 
-	h.Log.Debug("member handler::add call gRPC /BoardClient/UpdateBoard")
-	if _, err := h.BoardService.UpdateBoard(ctx, dto.toUpdate()); err != nil {
-		_ = c.Error(err)
-		return
+	httputil.NoContent(c)
+}
+
+// SaveMember adds the member to the board or updates their roles, the caller checks SAVE_MEMBER.
+func (h *Handler) SaveMember(ctx context.Context, board *v1Board.BoardsResponse_Board, dto SaveMemberDTO) error {
+	saga := handlers.NewSaga(h.Log)
+
+	h.Log.Debug("member handler::add call gRPC /SubjectClient/SaveSub")
+	if err := saga.Do(ctx, "SaveSub", func(ctx context.Context) error {
+		_, err := h.SubjectService.SaveSub(ctx, dto.toSaveSub())
+		return err
+	}, func(ctx context.Context) error {
+		_, err := h.SubjectService.SaveSub(ctx, restoreSub(board, dto.MemberID))
+		return err
+	}); err != nil {
+		return err
 	}
 
-	httputil.NoContent(c)
+	h.Log.Debug("member handler::add call gRPC /BoardClient/UpdateBoard")
+	return saga.Do(ctx, "UpdateBoard", func(ctx context.Context) error {
+		_, err := h.BoardService.UpdateBoard(ctx, dto.toUpdate())
+		return err
+	}, nil)
 }
 
 // @Summary Delete Board Member
@@ -78,7 +154,7 @@ func (h *Handler) save(c *gin.Context) {
 // @Router /boards/{board_id}/members/{member_id} [delete]
 // @Security BearerAuth
 func (h *Handler) delete(c *gin.Context) {
-	ctx := context.TODO()
+	ctx := httputil.GRPCAuth(c)
 
 	h.Log.Debug("member handler::delete bind")
 	var dto DeleteMemberDTO
@@ -86,18 +162,34 @@ func (h *Handler) delete(c *gin.Context) {
 		return
 	}
 
-	if !h.IsGranted(ctx, c, dto.BoardID, "DELETE_MEMBER") {
+	board, ok := h.GrantedBoard(ctx, c, dto.BoardID, "DELETE_MEMBER")
+	if !ok {
 		return
 	}
 
+	saga := handlers.NewSaga(h.Log)
+
 	h.Log.Debug("member handler::delete call gRPC /BoardClient/UpdateBoard")
-	if _, err := h.BoardService.UpdateBoard(ctx, dto.toUpdate()); err != nil {
+	if err := saga.Do(ctx, "UpdateBoard", func(ctx context.Context) error {
+		_, err := h.BoardService.UpdateBoard(ctx, dto.toUpdate())
+		return err
+	}, func(ctx context.Context) error {
+		req, found := restoreMember(board, dto.MemberID)
+		if !found {
+			return nil
+		}
+		_, err := h.BoardService.UpdateBoard(ctx, req)
+		return err
+	}); err != nil {
 		_ = c.Error(err)
 		return
 	}
 
-	h.Log.Debug("member handler::add call gRPC /SubjectClient/SaveSub")
-	if _, err := h.SubjectService.SaveSub(ctx, dto.toSaveSub()); err != nil {
+	h.Log.Debug("member handler::delete call gRPC /SubjectClient/SaveSub")
+	if err := saga.Do(ctx, "SaveSub", func(ctx context.Context) error {
+		_, err := h.SubjectService.SaveSub(ctx, dto.toSaveSub())
+		return err
+	}, nil); err != nil {
 		_ = c.Error(err)
 		return
 	}
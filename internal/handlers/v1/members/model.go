@@ -1,10 +1,95 @@
 package members
 
 import (
+	"github.com/go-funcards/funapi/internal/gin/httputil"
 	v1Authz "github.com/go-funcards/funapi/proto/authz_service/v1"
 	v1Board "github.com/go-funcards/funapi/proto/board_service/v1"
+	v1User "github.com/go-funcards/funapi/proto/user_service/v1"
+	"github.com/go-funcards/slice"
 )
 
+// OwnerRole is the role the owner of the board is listed with among its members.
+const OwnerRole = "OWNER"
+
+type Member struct {
+	MemberID string `json:"member_id"`
+	Name     string `json:"name"`
+	// Email is shown to the member and to users allowed to save members of the board
+	Email string   `json:"email,omitempty"`
+	Roles []string `json:"roles"`
+}
+
+type PageRequest struct {
+	httputil.PageRequest
+	UserID  string `json:"-" ctx:"user_id" validate:"required,uuid4"`
+	BoardID string `json:"-" uri:"board_id" validate:"required,uuid4"`
+}
+
+type PageResponse struct {
+	httputil.PageResponse
+	Data []Member `json:"data"`
+}
+
+// membersPage returns the members of the board on the page. The owner is the first member with OwnerRole,
+// the other members are ordered as the board holds them.
+func membersPage(board *v1Board.BoardsResponse_Board, index uint64, size uint32) ([]*v1Board.BoardsResponse_Board_Member, uint64) {
+	members := make([]*v1Board.BoardsResponse_Board_Member, 0, len(board.GetMembers())+1)
+	members = append(members, &v1Board.BoardsResponse_Board_Member{MemberId: board.GetOwnerId(), Roles: []string{OwnerRole}})
+	for _, m := range board.GetMembers() {
+		if m.GetMemberId() != board.GetOwnerId() {
+			members = append(members, m)
+		}
+	}
+	total := uint64(len(members))
+	from := index * uint64(size)
+	if from >= total {
+		return nil, total
+	}
+	to := from + uint64(size)
+	if to > total {
+		to = total
+	}
+	return members[from:to], total
+}
+
+func toUsers(members []*v1Board.BoardsResponse_Board_Member) *v1User.UsersRequest {
+	return &v1User.UsersRequest{
+		PageIndex: 0,
+		PageSize:  uint32(len(members)),
+		UserIds:   slice.Map(members, (*v1Board.BoardsResponse_Board_Member).GetMemberId),
+	}
+}
+
+func PageReq() PageRequest {
+	return PageRequest{PageRequest: httputil.PageRequest{Size: 1}}
+}
+
+// PageResp joins the members with their users, emails of other users are shown when showEmails is set.
+// Members whose user is not found have no name.
+func PageResp(members []*v1Board.BoardsResponse_Board_Member, users []*v1User.UserResponse, page httputil.PageResponse, userID string, showEmails bool) PageResponse {
+	byID := make(map[string]*v1User.UserResponse, len(users))
+	for _, u := range users {
+		byID[u.GetUserId()] = u
+	}
+
+	return PageResponse{
+		PageResponse: page,
+		Data: slice.Map(members, func(m *v1Board.BoardsResponse_Board_Member) Member {
+			member := Member{
+				MemberID: m.GetMemberId(),
+				Roles:    slice.Copy(m.GetRoles()),
+			}
+			if u, ok := byID[m.GetMemberId()]; ok {
+				member.Name = u.GetName()
+				if showEmails || u.GetUserId() == userID {
+					member.Email = u.GetEmail()
+				}
+			}
+			return member
+		}),
+	}
+}
+
 type SaveMemberDTO struct {
 	BoardID  string   `json:"-" uri:"board_id" validate:"required,uuid4"`
 	MemberID string   `json:"-" uri:"member_id" validate:"required,uuid4"`
@@ -51,3 +136,26 @@ func (dto DeleteMemberDTO) toSaveSub() *v1Authz.SaveSubRequest {
 		},
 	}
 }
+
+func findMember(board *v1Board.BoardsResponse_Board, memberID string) (*v1Board.BoardsResponse_Board_Member, bool) {
+	member, err := slice.Find(board.GetMembers(), func(m *v1Board.BoardsResponse_Board_Member) bool {
+		return m.GetMemberId() == memberID
+	})
+	return member, err == nil
+}
+
+// restoreSub reverts the member reference of the subject to its state before the board was changed.
+func restoreSub(board *v1Board.BoardsResponse_Board, memberID string) *v1Authz.SaveSubRequest {
+	if member, ok := findMember(board, memberID); ok {
+		return SaveMemberDTO{BoardID: board.GetBoardId(), MemberID: memberID, Roles: member.GetRoles()}.toSaveSub()
+	}
+	return DeleteMemberDTO{BoardID: board.GetBoardId(), MemberID: memberID}.toSaveSub()
+}
+
+// restoreMember adds the member back to the board with its roles before the board was changed.
+func restoreMember(board *v1Board.BoardsResponse_Board, memberID string) (*v1Board.UpdateBoardRequest, bool) {
+	if member, ok := findMember(board, memberID); ok {
+		return SaveMemberDTO{BoardID: board.GetBoardId(), MemberID: memberID, Roles: member.GetRoles()}.toUpdate(), true
+	}
+	return nil, false
+}
@@ -0,0 +1,123 @@
+package search
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/go-funcards/funapi/internal/gin/binding"
+	"github.com/go-funcards/funapi/internal/gin/httputil"
+	"github.com/go-funcards/funapi/internal/handlers"
+	"github.com/go-funcards/funapi/internal/handlers/v1/clientutil"
+	"github.com/go-funcards/funapi/internal/search"
+	v1Board "github.com/go-funcards/funapi/proto/board_service/v1"
+	"github.com/go-funcards/slice"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+var _ handlers.Handler = (*Handler)(nil)
+
+type Handler struct {
+	BoardService v1Board.BoardClient
+	// Cards, Categories and Tags check READ on hits of their type
+	Cards      *handlers.BaseBoard
+	Categories *handlers.BaseBoard
+	Tags       *handlers.BaseBoard
+	Index      *search.Index
+	Log        *zap.Logger
+}
+
+func (h *Handler) Register(rg *gin.RouterGroup) {
+	rg.GET("/search", h.search)
+}
+
+// @Summary Search
+// @Tags Search
+// @Description Search cards, categories and tags of boards owned by or shared with authenticated user.
+// @Description A result contains all terms of the query, the best matches first. Results the user may not read
+// @Description are omitted, so a page may contain fewer results than page_size.
+// @ModuleID search
+// @Produce json
+// @Param q query string true "Query" maxlength(200)
+// @Param page_index query int false "Page Index" minimum(0)
+// @Param page_size query int false "Page Size" minimum(1) maximum(1000)
+// @Param cursor query string false "Cursor of the next page, takes precedence over page_index"
+// @Success 200 {object} search.PageResponse
+// @Failure 400,401,500 {object} httputil.APIError
+// @Router /search [get]
+// @Security BearerAuth
+func (h *Handler) search(c *gin.Context) {
+	h.Log.Debug("search handler::search bind")
+	req := PageReq()
+	if !binding.BindCtx(c, &req) || !binding.BindQueryAndValidate(c, &req) {
+		return
+	}
+
+	ctx := httputil.GRPCAuth(c)
+
+	h.Log.Debug("search handler::search call gRPC /BoardClient/GetBoards")
+	boards, err := clientutil.GetAllBoards(ctx, h.BoardService, req.toBoards())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	boardIDs := slice.Map(boards, func(b *v1Board.BoardsResponse_Board) string {
+		return b.GetBoardId()
+	})
+
+	hits, page, err := httputil.Paginate(c, req.PageRequest, func(index uint64, size uint32) ([]search.Hit, uint64, error) {
+		return h.Index.Search(ctx, boardIDs, req.Query, int64(index)*int64(size), int64(size))
+	}, func(hit search.Hit) string {
+		return hit.ID
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	hits, ok := h.readable(ctx, c, hits)
+	if !ok {
+		return
+	}
+
+	httputil.NoCache(c)
+	c.JSON(http.StatusOK, PageResp(hits, page))
+}
+
+// readable omits hits the user may not read. Cards and categories are checked as children of their
+// boards, tags on their boards, each with the authz object of its type.
+func (h *Handler) readable(ctx context.Context, c *gin.Context, hits []search.Hit) ([]search.Hit, bool) {
+	byType := make(map[string][]search.Hit)
+	for _, hit := range hits {
+		byType[hit.Type] = append(byType[hit.Type], hit)
+	}
+
+	readable := make(map[string]bool, len(hits))
+	for typ, items := range byType {
+		var ok bool
+		switch typ {
+		case search.TypeCard:
+			items, ok = handlers.GrantedItems(ctx, c, h.Cards, items, hitBoardID, toChild, "READ")
+		case search.TypeCategory:
+			items, ok = handlers.GrantedItems(ctx, c, h.Categories, items, hitBoardID, toChild, "READ")
+		case search.TypeTag:
+			items, ok = handlers.GrantedItems(ctx, c, h.Tags, items, hitBoardID, nil, "READ")
+		default:
+			items, ok = nil, true
+		}
+		if !ok {
+			return nil, false
+		}
+		for _, hit := range items {
+			readable[hit.ID] = true
+		}
+	}
+
+	granted := make([]search.Hit, 0, len(hits))
+	for _, hit := range hits {
+		if readable[hit.ID] {
+			granted = append(granted, hit)
+		}
+	}
+	return granted, true
+}
@@ -0,0 +1,66 @@
+package search
+
+import (
+	v1Authz "github.com/go-funcards/funapi/internal/client/authz_service/v1"
+	"github.com/go-funcards/funapi/internal/gin/httputil"
+	"github.com/go-funcards/funapi/internal/search"
+	v1Board "github.com/go-funcards/funapi/proto/board_service/v1"
+	"github.com/go-funcards/slice"
+)
+
+type Hit struct {
+	ID      string `json:"id"`
+	Type    string `json:"type" enums:"card,category,tag"`
+	BoardID string `json:"board_id"`
+	// Name and Content are HTML escaped, matched terms are wrapped in <em> tags
+	Name    string  `json:"name"`
+	Content string  `json:"content,omitempty"`
+	Score   float64 `json:"score"`
+}
+
+type PageRequest struct {
+	httputil.PageRequest
+	UserID string `json:"-" ctx:"user_id" validate:"required,uuid4"`
+	Query  string `json:"-" form:"q" validate:"required,max=200"`
+}
+
+func (dto PageRequest) toBoards() *v1Board.BoardsRequest {
+	return &v1Board.BoardsRequest{
+		OwnerIds:  []string{dto.UserID},
+		MemberIds: []string{dto.UserID},
+	}
+}
+
+type PageResponse struct {
+	httputil.PageResponse
+	Data []Hit `json:"data"`
+}
+
+func hitBoardID(hit search.Hit) string {
+	return hit.BoardID
+}
+
+// toChild returns the authz object of the hit, as a child of its board.
+func toChild(hit search.Hit) v1Authz.Child {
+	return v1Authz.Child{ID: hit.ID, Owner: hit.Owner}
+}
+
+func PageReq() PageRequest {
+	return PageRequest{PageRequest: httputil.PageRequest{Size: 1}}
+}
+
+func PageResp(hits []search.Hit, page httputil.PageResponse) PageResponse {
+	return PageResponse{
+		PageResponse: page,
+		Data: slice.Map(hits, func(h search.Hit) Hit {
+			return Hit{
+				ID:      h.ID,
+				Type:    h.Type,
+				BoardID: h.BoardID,
+				Name:    h.Name,
+				Content: h.Content,
+				Score:   h.Score,
+			}
+		}),
+	}
+}
@@ -2,17 +2,26 @@ package session
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/go-funcards/funapi/internal/gin/binding"
 	"github.com/go-funcards/funapi/internal/gin/httputil"
 	"github.com/go-funcards/funapi/internal/handlers"
+	"github.com/go-funcards/funapi/internal/handlers/v1/clientutil"
+	"github.com/go-funcards/funapi/internal/notify"
+	"github.com/go-funcards/funapi/internal/throttle"
+	"github.com/go-funcards/funapi/internal/tokenstore"
 	v1Authz "github.com/go-funcards/funapi/proto/authz_service/v1"
 	v1User "github.com/go-funcards/funapi/proto/user_service/v1"
 	"github.com/go-funcards/jwt"
 	"github.com/go-funcards/token"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"net/http"
+	"time"
 )
 
 var _ handlers.Handler = (*Handler)(nil)
@@ -21,6 +30,15 @@ type Handler struct {
 	UserService    v1User.UserClient
 	SubjectService v1Authz.SubjectClient
 	TokenService   token.Service
+	LoginThrottle  *throttle.Login
+	TokenStorage   *tokenstore.Storage
+	DenyList       *tokenstore.DenyList
+	PasswordResets *tokenstore.OneTime
+	ResetURL       string
+	Verifications  *tokenstore.Verifications
+	VerifyURL      string
+	Notifier       notify.Notifier
+	Authorize      gin.HandlerFunc
 	Log            *zap.Logger
 }
 
@@ -30,6 +48,12 @@ func (h *Handler) Register(rg *gin.RouterGroup) {
 		g.POST("/create", h.create)
 		g.POST("/credentials", h.credentials)
 		g.POST("/refresh", h.refreshToken)
+		g.POST("/logout", h.Authorize, h.logout)
+		g.POST("/logout-all", h.Authorize, h.logoutAll)
+		g.POST("/password-reset/request", h.requestPasswordReset)
+		g.POST("/password-reset/confirm", h.confirmPasswordReset)
+		g.POST("/verify-email", h.verifyEmail)
+		g.POST("/verify-email/resend", h.Authorize, h.resendVerification)
 	}
 }
 
@@ -50,27 +74,57 @@ func (h *Handler) create(c *gin.Context) {
 		return
 	}
 
-	ctx := context.TODO()
+	ctx := httputil.GRPCAuth(c)
 	req := dto.toCreate(uuid.NewString(), "ROLE_USER")
 
-	if _, err := h.SubjectService.SaveSub(ctx, &v1Authz.SaveSubRequest{
-		SubId: req.GetUserId(),
-		Roles: req.GetRoles(),
+	saga := handlers.NewSaga(h.Log)
+
+	h.Log.Debug("session handler::create call gRPC /SubjectClient/SaveSub")
+	if err := saga.Do(ctx, "SaveSub", func(ctx context.Context) error {
+		_, err := h.SubjectService.SaveSub(ctx, &v1Authz.SaveSubRequest{
+			SubId: req.GetUserId(),
+			Roles: req.GetRoles(),
+		})
+		return err
+	}, func(ctx context.Context) error {
+		_, err := h.SubjectService.DeleteSub(ctx, &v1Authz.DeleteSubRequest{SubId: req.GetUserId()})
+		return err
+	}); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err := saga.Do(ctx, "MarkUnverified", func(ctx context.Context) error {
+		return h.Verifications.MarkUnverified(ctx, req.GetUserId())
+	}, func(ctx context.Context) error {
+		return h.Verifications.Forget(ctx, req.GetUserId())
 	}); err != nil {
 		_ = c.Error(err)
 		return
 	}
 
-	if _, err := h.UserService.CreateUser(ctx, req); err != nil {
+	h.Log.Debug("session handler::create call gRPC /UserClient/CreateUser")
+	if err := saga.Do(ctx, "CreateUser", func(ctx context.Context) error {
+		_, err := h.UserService.CreateUser(ctx, req)
+		return err
+	}, nil); err != nil {
 		_ = c.Error(err)
 		return
 	}
 
+	// the user is already unverified, it may resend the verification email
+	if err := h.SendVerification(ctx, req.GetUserId(), req.GetEmail()); err != nil {
+		h.Log.Error("session handler::create send verification", zap.Error(err))
+	}
+
 	session, err := h.TokenService.SessByUser(ctx, jwt.User{
 		UserID: req.GetUserId(),
 		Name:   req.GetName(),
 		Email:  req.GetEmail(),
 	})
+	if err == nil {
+		err = h.TokenStorage.StartSession(ctx, req.GetUserId(), session, h.client(c))
+	}
 	if err == nil {
 		h.session(c, session)
 	} else {
@@ -86,7 +140,8 @@ func (h *Handler) create(c *gin.Context) {
 // @Produce json
 // @Param payload body session.CredentialsDTO true "Credentials"
 // @Success 200 {object} token.Session
-// @Failure 400,404,422,500 {object} httputil.APIError
+// @Failure 400,404,422,429,500 {object} httputil.APIError
+// @Header 429 {integer} Retry-After "Seconds until the next attempt is allowed"
 // @Router /session/credentials [post]
 func (h *Handler) credentials(c *gin.Context) {
 	h.Log.Debug("session handler::credentials bind CredentialsDTO")
@@ -95,19 +150,36 @@ func (h *Handler) credentials(c *gin.Context) {
 		return
 	}
 
-	ctx := context.TODO()
+	ctx := httputil.GRPCAuth(c)
 
-	user, err := h.UserService.GetUserByEmailAndPassword(ctx, dto.toRequest())
+	retryAfter, err := h.LoginThrottle.Locked(ctx, dto.Email, c.ClientIP())
 	if err != nil {
 		_ = c.Error(err)
 		return
 	}
+	if retryAfter > 0 {
+		httputil.TooManyRequests(c, retryAfter)
+		return
+	}
+
+	user, err := h.UserService.GetUserByEmailAndPassword(ctx, dto.toRequest())
+	if err != nil {
+		h.loginFailed(c, dto.Email, err)
+		return
+	}
+
+	if err = h.LoginThrottle.Succeeded(ctx, dto.Email); err != nil {
+		h.Log.Warn("session handler::credentials reset login failures", zap.Error(err))
+	}
 
 	session, err := h.TokenService.SessByUser(ctx, jwt.User{
 		UserID: user.GetUserId(),
 		Name:   user.GetName(),
 		Email:  user.GetEmail(),
 	})
+	if err == nil {
+		err = h.TokenStorage.StartSession(ctx, user.GetUserId(), session, h.client(c))
+	}
 	if err == nil {
 		h.session(c, session)
 	} else {
@@ -132,7 +204,19 @@ func (h *Handler) refreshToken(c *gin.Context) {
 		return
 	}
 
-	session, err := h.TokenService.SessByRefreshToken(context.TODO(), dto.RefreshToken)
+	ctx := httputil.GRPCAuth(c)
+
+	user, err := h.TokenStorage.Get(ctx, dto.RefreshToken)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("invalid refresh token: %w", err))
+		return
+	}
+	sessionID, _ := h.TokenStorage.SessionID(ctx, dto.RefreshToken)
+
+	session, err := h.TokenService.SessByRefreshToken(ctx, dto.RefreshToken)
+	if err == nil {
+		err = h.TokenStorage.RefreshSession(ctx, user.UserID, sessionID, session, h.client(c))
+	}
 	if err == nil {
 		h.session(c, session)
 	} else {
@@ -140,7 +224,275 @@ func (h *Handler) refreshToken(c *gin.Context) {
 	}
 }
 
+// @Summary Logout
+// @Tags Session
+// @Description Revoke refresh token and current access token
+// @ModuleID logout
+// @Accept json
+// @Param payload body session.RefreshTokenDTO true "Refresh Token"
+// @Success 204
+// @Failure 400,401,404,422,500 {object} httputil.APIError
+// @Router /session/logout [post]
+// @Security BearerAuth
+func (h *Handler) logout(c *gin.Context) {
+	h.Log.Debug("session handler::logout bind RefreshTokenDTO")
+	var dto RefreshTokenDTO
+	if !binding.BindBodyAndValidate(c, &dto) {
+		return
+	}
+
+	ctx := httputil.GRPCAuth(c)
+
+	if err := h.TokenStorage.Revoke(ctx, httputil.GetUserID(c), dto.RefreshToken); err != nil {
+		if errors.Is(err, tokenstore.ErrNotFound) {
+			err = httputil.ErrNotFound
+		}
+		_ = c.Error(err)
+		return
+	}
+
+	if err := h.DenyList.DenyToken(ctx, httputil.GetAccessToken(c)); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	httputil.NoContent(c)
+}
+
+// @Summary Logout All Sessions
+// @Tags Session
+// @Description Revoke all refresh tokens and access tokens of authenticated user
+// @ModuleID logoutAll
+// @Success 204
+// @Failure 400,401,500 {object} httputil.APIError
+// @Router /session/logout-all [post]
+// @Security BearerAuth
+func (h *Handler) logoutAll(c *gin.Context) {
+	ctx, userID := httputil.CtxWithUserID(c)
+
+	h.Log.Debug("session handler::logoutAll revoke refresh tokens")
+	if err := h.TokenStorage.RevokeAll(ctx, userID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	h.Log.Debug("session handler::logoutAll revoke access tokens")
+	if err := h.DenyList.DenyUser(ctx, userID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	httputil.NoContent(c)
+}
+
+// @Summary Request Password Reset
+// @Tags Session
+// @Description Send a one-time password reset token to the user, responds the same whether the email is registered or not
+// @ModuleID requestPasswordReset
+// @Accept json
+// @Param payload body session.PasswordResetRequestDTO true "Email"
+// @Success 204
+// @Failure 400,422,500 {object} httputil.APIError
+// @Router /session/password-reset/request [post]
+func (h *Handler) requestPasswordReset(c *gin.Context) {
+	h.Log.Debug("session handler::requestPasswordReset bind PasswordResetRequestDTO")
+	var dto PasswordResetRequestDTO
+	if !binding.BindBodyAndValidate(c, &dto) {
+		return
+	}
+
+	ctx := httputil.GRPCAuth(c)
+
+	h.Log.Debug("session handler::requestPasswordReset call gRPC /UserClient/GetUsers")
+	user, err := clientutil.GetUserByEmail(ctx, h.UserService, dto.Email)
+	if err != nil {
+		if !errors.Is(err, httputil.ErrNotFound) {
+			_ = c.Error(err)
+		} else {
+			httputil.NoContent(c)
+		}
+		return
+	}
+
+	token, err := h.PasswordResets.Issue(ctx, user.GetUserId())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err = h.Notifier.Notify(ctx, passwordResetMessage(user.GetEmail(), token, h.ResetURL)); err != nil {
+		h.Log.Error("session handler::requestPasswordReset notify", zap.Error(err))
+	}
+
+	httputil.NoContent(c)
+}
+
+// @Summary Confirm Password Reset
+// @Tags Session
+// @Description Set a new password by one-time token and revoke all sessions of the user
+// @ModuleID confirmPasswordReset
+// @Accept json
+// @Param payload body session.PasswordResetConfirmDTO true "Token and new password"
+// @Success 204
+// @Failure 400,404,422,500 {object} httputil.APIError
+// @Router /session/password-reset/confirm [post]
+func (h *Handler) confirmPasswordReset(c *gin.Context) {
+	h.Log.Debug("session handler::confirmPasswordReset bind PasswordResetConfirmDTO")
+	var dto PasswordResetConfirmDTO
+	if !binding.BindBodyAndValidate(c, &dto) {
+		return
+	}
+
+	ctx := httputil.GRPCAuth(c)
+
+	saga := handlers.NewSaga(h.Log)
+
+	// the token is claimed before the update and put back when the update fails
+	var (
+		userID string
+		ttl    time.Duration
+	)
+	if err := saga.Do(ctx, "ClaimToken", func(ctx context.Context) (err error) {
+		userID, ttl, err = h.PasswordResets.Claim(ctx, dto.Token)
+		if errors.Is(err, tokenstore.ErrNotFound) {
+			err = httputil.ErrNotFound
+		}
+		return err
+	}, func(ctx context.Context) error {
+		return h.PasswordResets.Unclaim(ctx, dto.Token, userID, ttl)
+	}); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	h.Log.Debug("session handler::confirmPasswordReset call gRPC /UserClient/UpdateUser")
+	if err := saga.Do(ctx, "UpdateUser", func(ctx context.Context) error {
+		_, err := h.UserService.UpdateUser(ctx, dto.toUpdate(userID))
+		return err
+	}, nil); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err := h.TokenStorage.RevokeAll(ctx, userID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err := h.DenyList.DenyUser(ctx, userID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	httputil.NoContent(c)
+}
+
+// @Summary Verify Email
+// @Tags Session
+// @Description Confirm email address of the user by verification token
+// @ModuleID verifyEmail
+// @Accept json
+// @Param payload body session.VerifyEmailDTO true "Verification token"
+// @Success 204
+// @Failure 400,404,422,500 {object} httputil.APIError
+// @Router /session/verify-email [post]
+func (h *Handler) verifyEmail(c *gin.Context) {
+	h.Log.Debug("session handler::verifyEmail bind VerifyEmailDTO")
+	var dto VerifyEmailDTO
+	if !binding.BindBodyAndValidate(c, &dto) {
+		return
+	}
+
+	if _, err := h.Verifications.Confirm(httputil.GRPCAuth(c), dto.Token); err != nil {
+		if errors.Is(err, tokenstore.ErrNotFound) {
+			err = httputil.ErrNotFound
+		}
+		_ = c.Error(err)
+		return
+	}
+
+	httputil.NoContent(c)
+}
+
+// @Summary Resend Verification Email
+// @Tags Session
+// @Description Send a new verification token to the email of authenticated user
+// @ModuleID resendVerification
+// @Success 204
+// @Failure 400,401,409,500 {object} httputil.APIError
+// @Router /session/verify-email/resend [post]
+// @Security BearerAuth
+func (h *Handler) resendVerification(c *gin.Context) {
+	ctx, userID := httputil.CtxWithUserID(c)
+
+	verified, err := h.Verifications.IsVerified(ctx, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if verified {
+		_ = c.Error(httputil.ErrConflict)
+		return
+	}
+
+	h.Log.Debug("session handler::resendVerification call gRPC /UserClient/GetUsers")
+	user, err := clientutil.GetUser(ctx, h.UserService, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err = h.SendVerification(ctx, userID, user.GetEmail()); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	httputil.NoContent(c)
+}
+
+// SendVerification marks the user unverified and sends a verification token to the email.
+func (h *Handler) SendVerification(ctx context.Context, userID, email string) error {
+	token, err := h.Verifications.Issue(ctx, userID, email)
+	if err != nil {
+		return err
+	}
+	if err = h.Notifier.Notify(ctx, verificationMessage(email, token, h.VerifyURL)); err != nil {
+		h.Log.Error("session handler::SendVerification notify", zap.Error(err))
+	}
+	return nil
+}
+
 func (h *Handler) session(c *gin.Context, session token.Session) {
 	httputil.NoCache(c)
 	c.JSON(http.StatusOK, session)
 }
+
+func (h *Handler) loginFailed(c *gin.Context, email string, err error) {
+	if code := status.Code(err); code != codes.NotFound && code != codes.Unauthenticated {
+		_ = c.Error(err)
+		return
+	}
+
+	delay, retryAfter, ferr := h.LoginThrottle.Failed(c.Request.Context(), email, c.ClientIP())
+	if ferr != nil {
+		_ = c.Error(ferr)
+		return
+	}
+	if retryAfter > 0 {
+		httputil.TooManyRequests(c, retryAfter)
+		return
+	}
+
+	select {
+	case <-time.After(delay):
+	case <-c.Request.Context().Done():
+	}
+	_ = c.Error(err)
+}
+
+func (h *Handler) client(c *gin.Context) tokenstore.Client {
+	return tokenstore.Client{
+		UserAgent: c.Request.UserAgent(),
+		IP:        c.ClientIP(),
+	}
+}
@@ -1,7 +1,10 @@
 package session
 
 import (
+	"fmt"
+	"github.com/go-funcards/funapi/internal/notify"
 	"github.com/go-funcards/funapi/proto/user_service/v1"
+	"net/url"
 )
 
 type CreateUserDTO struct {
@@ -36,3 +39,47 @@ func (dto CredentialsDTO) toRequest() *v1.UserByEmailAndPasswordRequest {
 type RefreshTokenDTO struct {
 	RefreshToken string `json:"refresh_token" validate:"required,uuid4"`
 }
+
+type PasswordResetRequestDTO struct {
+	Email string `json:"email" validate:"required,email,min=3,max=255"`
+}
+
+type PasswordResetConfirmDTO struct {
+	Token          string `json:"token" validate:"required,max=100"`
+	Password       string `json:"password" validate:"required,eqfield=RepeatPassword,min=8,max=64"`
+	RepeatPassword string `json:"repeat_password" validate:"required,min=8,max=64"`
+}
+
+func (dto PasswordResetConfirmDTO) toUpdate(userID string) *v1.UpdateUserRequest {
+	return &v1.UpdateUserRequest{
+		UserId:      userID,
+		NewPassword: dto.Password,
+	}
+}
+
+type VerifyEmailDTO struct {
+	Token string `json:"token" validate:"required,max=100"`
+}
+
+func passwordResetMessage(email, token, resetURL string) notify.Message {
+	return notify.Message{
+		To:      email,
+		Subject: "Reset your FunCards password",
+		Body:    fmt.Sprintf("Use the following to reset your password, it can be used only once:\n\n%s\n\nIf you did not request a password reset, ignore this message.", tokenLink(token, resetURL)),
+	}
+}
+
+func verificationMessage(email, token, verifyURL string) notify.Message {
+	return notify.Message{
+		To:      email,
+		Subject: "Verify your FunCards email",
+		Body:    fmt.Sprintf("Use the following to verify your email address:\n\n%s\n\nIf you did not create a FunCards account, ignore this message.", tokenLink(token, verifyURL)),
+	}
+}
+
+func tokenLink(token, baseURL string) string {
+	if len(baseURL) == 0 {
+		return token
+	}
+	return fmt.Sprintf("%s?token=%s", baseURL, url.QueryEscape(token))
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/go-funcards/funapi/internal/gin/httputil"
 	"github.com/go-funcards/funapi/internal/handlers"
 	"github.com/go-funcards/funapi/internal/handlers/v1/clientutil"
+	"github.com/go-funcards/funapi/internal/search"
 	v1Tag "github.com/go-funcards/funapi/proto/tag_service/v1"
+	"github.com/go-funcards/slice"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"net/http"
@@ -18,6 +20,7 @@ var _ handlers.Handler = (*Handler)(nil)
 type Handler struct {
 	*handlers.BaseBoard
 	TagService v1Tag.TagClient
+	Search     *search.Index
 	Log        *zap.Logger
 }
 
@@ -26,6 +29,8 @@ func (h *Handler) Register(rg *gin.RouterGroup) {
 	{
 		g.GET("", h.list)
 		g.POST("", h.create)
+		g.POST("/batch", h.createMany)
+		g.DELETE("", h.deleteMany)
 
 		b := g.Group("/:tag_id")
 		{
@@ -41,9 +46,13 @@ func (h *Handler) Register(rg *gin.RouterGroup) {
 // @ModuleID listTag
 // @Accept json
 // @Produce json
-// @Param board_id query string true "Board ID" format(uuid)
+// @Param board_id query []string true "Board IDs" collectionFormat(multi) maxItems(100)
+// @Param tag_id query []string false "Tag IDs" collectionFormat(multi) maxItems(100)
+// @Param owner_id query []string false "Owner IDs" collectionFormat(multi) maxItems(100)
 // @Param page_index query int false "Page Index" minimum(0)
 // @Param page_size query int false "Page Size" minimum(1) maximum(1000)
+// @Param cursor query string false "Cursor of the next page, takes precedence over page_index. It holds the offset and the ID of the last returned item, not a sort key, so it cannot be combined with sort"
+// @Param sort query string false "Comma separated fields, prefixed with - for descending order: color, created_at, name. Supported only when the result fits on the first page"
 // @Success 200 {object} tags.PageResponse
 // @Failure 400,401,403,500 {object} httputil.APIError
 // @Router /tags [get]
@@ -55,20 +64,33 @@ func (h *Handler) list(c *gin.Context) {
 		return
 	}
 
-	ctx := context.TODO()
+	if err := req.Sort.CheckFirstPage(req.PageRequest); err != nil {
+		_ = c.Error(err)
+		return
+	}
 
-	if !h.IsGranted(ctx, c, req.BoardID, "READ") {
+	ctx := httputil.GRPCAuth(c)
+
+	if !h.AreGranted(ctx, c, req.BoardIDs, "READ") {
 		return
 	}
 
-	h.Log.Debug("tag handler::list call gRPC /TagClient/GetTags")
-	response, err := h.TagService.GetTags(ctx, req.toRead())
+	items, page, err := httputil.Paginate(c, req.PageRequest, func(index uint64, size uint32) ([]*v1Tag.TagsResponse_Tag, uint64, error) {
+		h.Log.Debug("tag handler::list call gRPC /TagClient/GetTags")
+		response, err := h.TagService.GetTags(ctx, req.toRead(index, size))
+		return response.GetTags(), response.GetTotal(), err
+	}, (*v1Tag.TagsResponse_Tag).GetTagId)
 	if err != nil {
 		_ = c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, PageResp(response, req))
+	if err = req.Sort.CheckSortable(req.PageRequest, page.Total); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, PageResp(items, page, req))
 }
 
 // @Summary Create Tag
@@ -88,7 +110,7 @@ func (h *Handler) create(c *gin.Context) {
 		return
 	}
 
-	ctx := context.TODO()
+	ctx := httputil.GRPCAuth(c)
 
 	if !h.IsGranted(ctx, c, dto.BoardID, "CREATE") {
 		return
@@ -102,9 +124,86 @@ func (h *Handler) create(c *gin.Context) {
 		return
 	}
 
+	h.reindex(ctx, id)
+
 	httputil.Created(c, id)
 }
 
+// @Summary Create Many Tags
+// @Description Items are validated and created one by one, CREATE is checked once per board.
+// @Tags Tags
+// @ModuleID createManyTags
+// @Accept json
+// @Produce json
+// @Param payload body tags.CreateManyTagsDTO true "Tags data"
+// @Success 207 {object} httputil.BatchResponse
+// @Failure 400,401,422,500 {object} httputil.APIError
+// @Router /tags/batch [post]
+// @Security BearerAuth
+func (h *Handler) createMany(c *gin.Context) {
+	h.Log.Debug("tag handler::createMany bind")
+	var dto CreateManyTagsDTO
+	if !binding.BindCtx(c, &dto) || !binding.BindBodyAndValidate(c, &dto) {
+		return
+	}
+
+	ctx := httputil.GRPCAuth(c)
+
+	h.Log.Debug("tag handler::createMany call gRPC /TagClient/CreateTag")
+	results := handlers.CreateMany(ctx, c, h.BaseBoard, dto.items(), func(item CreateTagDTO) string {
+		return item.BoardID
+	}, func(ctx context.Context, item CreateTagDTO, id string) error {
+		_, err := h.TagService.CreateTag(ctx, item.toCreate(id))
+		return err
+	})
+
+	h.reindex(ctx, handlers.CreatedIDs(results)...)
+
+	httputil.MultiStatus(c, results)
+}
+
+// @Summary Delete Many Tags
+// @Description DELETE is checked once per board.
+// @Tags Tags
+// @ModuleID deleteManyTags
+// @Accept json
+// @Produce json
+// @Param payload body tags.DeleteManyTagsDTO true "Tags IDs"
+// @Success 207 {object} httputil.BatchResponse
+// @Failure 400,401,422,500 {object} httputil.APIError
+// @Router /tags [delete]
+// @Security BearerAuth
+func (h *Handler) deleteMany(c *gin.Context) {
+	h.Log.Debug("tag handler::deleteMany bind")
+	var dto DeleteManyTagsDTO
+	if !binding.BindBodyAndValidate(c, &dto) {
+		return
+	}
+
+	ctx := httputil.GRPCAuth(c)
+
+	h.Log.Debug("tag handler::deleteMany call gRPC /TagClient/GetTags")
+	response, err := h.TagService.GetTags(ctx, dto.toRead())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	results := handlers.DeleteMany(ctx, c, h.BaseBoard, dto.IDs, response.GetTags(),
+		(*v1Tag.TagsResponse_Tag).GetTagId,
+		(*v1Tag.TagsResponse_Tag).GetBoardId,
+		nil,
+		func(ctx context.Context, tag *v1Tag.TagsResponse_Tag) error {
+			if _, err := h.TagService.DeleteTag(ctx, &v1Tag.DeleteTagRequest{TagId: tag.GetTagId()}); err != nil {
+				return err
+			}
+			h.Search.Remove(ctx, tag.GetTagId())
+			return nil
+		})
+
+	httputil.MultiStatus(c, results)
+}
+
 // @Summary Read Tag
 // @Tags Tags
 // @ModuleID readTag
@@ -122,7 +221,7 @@ func (h *Handler) read(c *gin.Context) {
 		return
 	}
 
-	ctx := context.TODO()
+	ctx := httputil.GRPCAuth(c)
 
 	h.Log.Debug("tag handler::read call gRPC /TagClient/GetTags")
 	tag, err := clientutil.GetTag(ctx, h.TagService, dto.TagID)
@@ -155,7 +254,7 @@ func (h *Handler) update(c *gin.Context) {
 		return
 	}
 
-	ctx := context.TODO()
+	ctx := httputil.GRPCAuth(c)
 
 	h.Log.Debug("tag handler::update call gRPC /TagClient/GetTags")
 	tag, err := clientutil.GetTag(ctx, h.TagService, dto.TagID)
@@ -178,6 +277,8 @@ func (h *Handler) update(c *gin.Context) {
 		return
 	}
 
+	h.reindex(ctx, dto.TagID)
+
 	httputil.NoContent(c)
 }
 
@@ -196,7 +297,7 @@ func (h *Handler) delete(c *gin.Context) {
 		return
 	}
 
-	ctx := context.TODO()
+	ctx := httputil.GRPCAuth(c)
 
 	h.Log.Debug("tag handler::delete call gRPC /TagClient/GetTags")
 	tag, err := clientutil.GetTag(ctx, h.TagService, dto.TagID)
@@ -215,5 +316,27 @@ func (h *Handler) delete(c *gin.Context) {
 		return
 	}
 
+	h.Search.Remove(ctx, dto.TagID)
+
 	httputil.NoContent(c)
 }
+
+// reindex feeds the search index with the current state of the tags.
+func (h *Handler) reindex(ctx context.Context, ids ...string) {
+	if len(ids) == 0 {
+		return
+	}
+
+	h.Log.Debug("tag handler::reindex call gRPC /TagClient/GetTags")
+	response, err := h.TagService.GetTags(ctx, &v1Tag.TagsRequest{
+		PageIndex: 0,
+		PageSize:  uint32(len(ids)),
+		TagIds:    ids,
+	})
+	if err != nil {
+		h.Log.Error("tag handler::reindex", zap.Error(err))
+		return
+	}
+
+	h.Search.Add(ctx, slice.Map(response.GetTags(), search.TagDocument)...)
+}
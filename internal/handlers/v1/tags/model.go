@@ -23,14 +23,19 @@ type PageResponse struct {
 
 type PageRequest struct {
 	httputil.PageRequest
-	BoardID string `json:"-" form:"board_id" validate:"required,uuid4"`
+	BoardIDs []string      `json:"-" form:"board_id" validate:"required,min=1,max=100,unique,dive,uuid4"`
+	TagIDs   []string      `json:"-" form:"tag_id" validate:"omitempty,max=100,unique,dive,uuid4"`
+	OwnerIDs []string      `json:"-" form:"owner_id" validate:"omitempty,max=100,unique,dive,uuid4"`
+	Sort     httputil.Sort `json:"-" form:"sort" validate:"omitempty,max=200,sort=color created_at name"`
 }
 
-func (dto PageRequest) toRead() *v1.TagsRequest {
+func (dto PageRequest) toRead(index uint64, size uint32) *v1.TagsRequest {
 	return &v1.TagsRequest{
-		PageIndex: dto.Index,
-		PageSize:  dto.Size,
-		BoardIds:  []string{dto.BoardID},
+		PageIndex: index,
+		PageSize:  size,
+		BoardIds:  dto.BoardIDs,
+		TagIds:    dto.TagIDs,
+		OwnerIds:  dto.OwnerIDs,
 	}
 }
 
@@ -51,6 +56,19 @@ func (dto CreateTagDTO) toCreate(id string) *v1.CreateTagRequest {
 	}
 }
 
+type CreateManyTagsDTO struct {
+	OwnerID string         `json:"-" ctx:"user_id" validate:"required,uuid4"`
+	Data    []CreateTagDTO `json:"data" validate:"required,min=1,max=1000"`
+}
+
+// items returns the items owned by the caller, they are validated one by one.
+func (dto CreateManyTagsDTO) items() []CreateTagDTO {
+	return slice.Map(dto.Data, func(item CreateTagDTO) CreateTagDTO {
+		item.OwnerID = dto.OwnerID
+		return item
+	})
+}
+
 type UpdateTagDTO struct {
 	TagID   string `json:"-" uri:"tag_id" validate:"required,uuid4"`
 	BoardID string `json:"board_id" validate:"omitempty,uuid4" format:"uuid"`
@@ -71,6 +89,18 @@ type ReadTagDTO struct {
 	TagID string `json:"-" uri:"tag_id" validate:"required,uuid4"`
 }
 
+type DeleteManyTagsDTO struct {
+	IDs []string `json:"ids" validate:"required,min=1,max=1000,unique,dive,uuid4"`
+}
+
+func (dto DeleteManyTagsDTO) toRead() *v1.TagsRequest {
+	return &v1.TagsRequest{
+		PageIndex: 0,
+		PageSize:  uint32(len(dto.IDs)),
+		TagIds:    dto.IDs,
+	}
+}
+
 type DeleteTagDTO struct {
 	TagID string `json:"-" uri:"tag_id" validate:"required,uuid4"`
 }
@@ -85,13 +115,28 @@ func PageReq() PageRequest {
 	return PageRequest{PageRequest: httputil.PageRequest{Size: 1}}
 }
 
-func PageResp(response *v1.TagsResponse, req PageRequest) PageResponse {
+func PageResp(items []*v1.TagsResponse_Tag, page httputil.PageResponse, req PageRequest) PageResponse {
+	data := slice.Map(items, func(b *v1.TagsResponse_Tag) Tag {
+		return CreateTag(b)
+	})
+	httputil.SortSlice(data, req.Sort, compareTags)
+
 	return PageResponse{
-		PageResponse: req.ToPageResponse(response.GetTotal()),
-		Data: slice.Map(response.GetTags(), func(b *v1.TagsResponse_Tag) Tag {
-			return CreateTag(b)
-		}),
+		PageResponse: page,
+		Data:         data,
+	}
+}
+
+func compareTags(a, b Tag, field string) int {
+	switch field {
+	case "name":
+		return httputil.CompareStrings(a.Name, b.Name)
+	case "color":
+		return httputil.CompareStrings(a.Color, b.Color)
+	case "created_at":
+		return httputil.CompareTimes(a.CreatedAt, b.CreatedAt)
 	}
+	return 0
 }
 
 func CreateTag(response *v1.TagsResponse_Tag) Tag {
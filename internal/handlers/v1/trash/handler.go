@@ -0,0 +1,276 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/go-funcards/funapi/internal/gin/binding"
+	"github.com/go-funcards/funapi/internal/gin/httputil"
+	"github.com/go-funcards/funapi/internal/handlers"
+	"github.com/go-funcards/funapi/internal/handlers/v1/clientutil"
+	"github.com/go-funcards/funapi/internal/jobs"
+	"github.com/go-funcards/funapi/internal/search"
+	"github.com/go-funcards/funapi/internal/trash"
+	v1Authz "github.com/go-funcards/funapi/proto/authz_service/v1"
+	v1Board "github.com/go-funcards/funapi/proto/board_service/v1"
+	v1Card "github.com/go-funcards/funapi/proto/card_service/v1"
+	v1Category "github.com/go-funcards/funapi/proto/category_service/v1"
+	v1Tag "github.com/go-funcards/funapi/proto/tag_service/v1"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+var _ handlers.Handler = (*Handler)(nil)
+
+type Handler struct {
+	BoardService    v1Board.BoardClient
+	CategoryService v1Category.CategoryClient
+	CardService     v1Card.CardClient
+	TagService      v1Tag.TagClient
+	SubjectService  v1Authz.SubjectClient
+	// Categories and Cards check the parent board of restored categories and cards
+	Categories *handlers.BaseBoard
+	Cards      *handlers.BaseBoard
+	Trash      *trash.Store
+	Deletion   *jobs.BoardDeletion
+	Search     *search.Index
+	Log        *zap.Logger
+}
+
+func (h *Handler) Register(rg *gin.RouterGroup) {
+	g := rg.Group("/trash")
+	{
+		g.GET("", h.list)
+		g.POST("/:id/restore", h.restore)
+	}
+}
+
+// @Summary Trash
+// @Tags Trash
+// @Description Return boards, categories and cards deleted by authenticated user
+// @ModuleID listTrash
+// @Produce json
+// @Param page_index query int false "Page Index" minimum(0)
+// @Param page_size query int false "Page Size" minimum(1) maximum(1000)
+// @Param cursor query string false "Cursor of the next page, takes precedence over page_index"
+// @Success 200 {object} trash.PageResponse
+// @Failure 400,401,500 {object} httputil.APIError
+// @Router /trash [get]
+// @Security BearerAuth
+func (h *Handler) list(c *gin.Context) {
+	h.Log.Debug("trash handler::list bind")
+	req := PageReq()
+	if !binding.BindCtx(c, &req) || !binding.BindQueryAndValidate(c, &req) {
+		return
+	}
+
+	ctx := httputil.GRPCAuth(c)
+
+	entries, page, err := httputil.Paginate(c, req.PageRequest, func(index uint64, size uint32) ([]trash.Entry, uint64, error) {
+		return h.Trash.List(ctx, req.UserID, index, size)
+	}, func(e trash.Entry) string {
+		return e.ID
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	httputil.NoCache(c)
+	c.JSON(http.StatusOK, PageResp(entries, page))
+}
+
+// @Summary Restore From Trash
+// @Tags Trash
+// @Description Restore a board including its members, a category or a card deleted by authenticated user
+// @ModuleID restoreTrash
+// @Param id path string true "Board, Category or Card ID" format(uuid)
+// @Success 204
+// @Failure 400,401,403,404,409,500 {object} httputil.APIError
+// @Router /trash/{id}/restore [post]
+// @Security BearerAuth
+func (h *Handler) restore(c *gin.Context) {
+	h.Log.Debug("trash handler::restore bind")
+	var dto RestoreDTO
+	if !binding.BindCtx(c, &dto) || !binding.BindUriAndValidate(c, &dto) {
+		return
+	}
+
+	ctx := httputil.GRPCAuth(c)
+
+	entry, err := h.Trash.Get(ctx, dto.ID)
+	if err == nil && entry.DeletedBy != dto.UserID {
+		err = trash.ErrNotFound
+	}
+	if err != nil {
+		if errors.Is(err, trash.ErrNotFound) {
+			err = httputil.ErrNotFound
+		}
+		_ = c.Error(err)
+		return
+	}
+
+	var restoreFn func(ctx context.Context, saga *handlers.Saga) error
+
+	switch entry.Type {
+	case trash.TypeBoard:
+		restoreFn = h.restoreBoard(entry)
+	case trash.TypeCategory:
+		if !h.Categories.IsGranted(ctx, c, entry.BoardID, "CREATE") {
+			return
+		}
+		restoreFn = h.restoreCategory(entry)
+	case trash.TypeCard:
+		if !h.Cards.IsGranted(ctx, c, entry.BoardID, "CREATE") {
+			return
+		}
+		restoreFn = h.restoreCard(entry)
+	default:
+		_ = c.Error(httputil.ErrNotFound)
+		return
+	}
+
+	saga := handlers.NewSaga(h.Log)
+
+	h.Log.Debug("trash handler::restore claim entry")
+	if err = saga.Do(ctx, "ClaimTrash", func(ctx context.Context) error {
+		claimed, err := h.Trash.Claim(ctx, entry.ID)
+		if err == nil && !claimed {
+			// the entry is being purged or restored by a concurrent request
+			err = httputil.ErrConflict
+		}
+		return err
+	}, func(ctx context.Context) error {
+		return h.Trash.Unclaim(ctx, entry)
+	}); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err = restoreFn(ctx, saga); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	h.Log.Debug("trash handler::restore remove entry")
+	if err = saga.Do(ctx, "RemoveTrash", func(ctx context.Context) error {
+		return h.Trash.Remove(ctx, entry)
+	}, nil); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	h.reindex(ctx, entry)
+
+	httputil.NoContent(c)
+}
+
+// reindex feeds the search index with the restored board contents, category or card.
+func (h *Handler) reindex(ctx context.Context, entry trash.Entry) {
+	var docs []search.Document
+	var err error
+
+	switch entry.Type {
+	case trash.TypeBoard:
+		docs, err = search.Sources{
+			CategoryService: h.CategoryService,
+			CardService:     h.CardService,
+			TagService:      h.TagService,
+		}.BoardDocuments(ctx, entry.ID)
+	case trash.TypeCategory:
+		var category *v1Category.CategoriesResponse_Category
+		if category, err = clientutil.GetCategory(ctx, h.CategoryService, entry.ID); err == nil {
+			docs = append(docs, search.CategoryDocument(category))
+		}
+	case trash.TypeCard:
+		var card *v1Card.CardsResponse_Card
+		if card, err = clientutil.GetCard(ctx, h.CardService, entry.ID); err == nil {
+			docs = append(docs, search.CardDocument(card))
+		}
+	}
+	if err != nil {
+		h.Log.Error("trash handler::reindex", zap.String("id", entry.ID), zap.Error(err))
+		return
+	}
+
+	h.Search.Add(ctx, docs...)
+}
+
+func (h *Handler) restoreBoard(entry trash.Entry) func(ctx context.Context, saga *handlers.Saga) error {
+	return func(ctx context.Context, saga *handlers.Saga) error {
+		req := new(v1Board.CreateBoardRequest)
+		if err := entry.Unmarshal(req); err != nil {
+			return err
+		}
+
+		h.Log.Debug("trash handler::restore call gRPC /BoardClient/CreateBoard")
+		if err := saga.Do(ctx, "CreateBoard", func(ctx context.Context) error {
+			_, err := h.BoardService.CreateBoard(ctx, req)
+			return err
+		}, func(ctx context.Context) error {
+			_, err := h.BoardService.DeleteBoard(ctx, &v1Board.DeleteBoardRequest{BoardId: req.GetBoardId()})
+			return err
+		}); err != nil {
+			return err
+		}
+
+		saves, deletes := memberSubs(req, false), memberSubs(req, true)
+		for i := range saves {
+			save, del := saves[i], deletes[i]
+
+			h.Log.Debug("trash handler::restore call gRPC /SubjectClient/SaveSub")
+			if err := saga.Do(ctx, "SaveSub", func(ctx context.Context) error {
+				_, err := h.SubjectService.SaveSub(ctx, save)
+				return err
+			}, func(ctx context.Context) error {
+				_, err := h.SubjectService.SaveSub(ctx, del)
+				return err
+			}); err != nil {
+				return err
+			}
+		}
+
+		h.Log.Debug("trash handler::restore cancel board deletion")
+		return saga.Do(ctx, "CancelDeletion", func(ctx context.Context) error {
+			return h.Deletion.Cancel(ctx, req.GetBoardId())
+		}, func(ctx context.Context) error {
+			return h.Deletion.Schedule(ctx, req.GetBoardId(), entry.DeletedBy, entry.ExpiresAt)
+		})
+	}
+}
+
+func (h *Handler) restoreCategory(entry trash.Entry) func(ctx context.Context, saga *handlers.Saga) error {
+	return func(ctx context.Context, saga *handlers.Saga) error {
+		req := new(v1Category.CreateCategoryRequest)
+		if err := entry.Unmarshal(req); err != nil {
+			return err
+		}
+
+		h.Log.Debug("trash handler::restore call gRPC /CategoryClient/CreateCategory")
+		return saga.Do(ctx, "CreateCategory", func(ctx context.Context) error {
+			_, err := h.CategoryService.CreateCategory(ctx, req)
+			return err
+		}, func(ctx context.Context) error {
+			_, err := h.CategoryService.DeleteCategory(ctx, &v1Category.DeleteCategoryRequest{CategoryId: req.GetCategoryId()})
+			return err
+		})
+	}
+}
+
+func (h *Handler) restoreCard(entry trash.Entry) func(ctx context.Context, saga *handlers.Saga) error {
+	return func(ctx context.Context, saga *handlers.Saga) error {
+		req := new(v1Card.CreateCardRequest)
+		if err := entry.Unmarshal(req); err != nil {
+			return err
+		}
+
+		h.Log.Debug("trash handler::restore call gRPC /CardClient/CreateCard")
+		return saga.Do(ctx, "CreateCard", func(ctx context.Context) error {
+			_, err := h.CardService.CreateCard(ctx, req)
+			return err
+		}, func(ctx context.Context) error {
+			_, err := h.CardService.DeleteCard(ctx, &v1Card.DeleteCardRequest{CardId: req.GetCardId()})
+			return err
+		})
+	}
+}
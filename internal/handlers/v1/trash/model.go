@@ -0,0 +1,67 @@
+package trash
+
+import (
+	"github.com/go-funcards/funapi/internal/gin/httputil"
+	"github.com/go-funcards/funapi/internal/trash"
+	v1Authz "github.com/go-funcards/funapi/proto/authz_service/v1"
+	v1Board "github.com/go-funcards/funapi/proto/board_service/v1"
+	"github.com/go-funcards/slice"
+	"time"
+)
+
+type Entry struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type" enums:"board,category,card"`
+	BoardID   string    `json:"board_id"`
+	Name      string    `json:"name"`
+	DeletedAt time.Time `json:"deleted_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type PageRequest struct {
+	httputil.PageRequest
+	UserID string `json:"-" ctx:"user_id" validate:"required,uuid4"`
+}
+
+type PageResponse struct {
+	httputil.PageResponse
+	Data []Entry `json:"data"`
+}
+
+type RestoreDTO struct {
+	UserID string `json:"-" ctx:"user_id" validate:"required,uuid4"`
+	ID     string `json:"-" uri:"id" validate:"required,uuid4"`
+}
+
+func memberSubs(board *v1Board.CreateBoardRequest, del bool) []*v1Authz.SaveSubRequest {
+	return slice.Map(board.GetMembers(), func(m *v1Board.CreateBoardRequest_Member) *v1Authz.SaveSubRequest {
+		ref := &v1Authz.SaveSubRequest_Ref{RefId: board.GetBoardId(), Delete: del}
+		if !del {
+			ref.Roles = m.GetRoles()
+		}
+		return &v1Authz.SaveSubRequest{
+			SubId: m.GetMemberId(),
+			Refs:  []*v1Authz.SaveSubRequest_Ref{ref},
+		}
+	})
+}
+
+func PageReq() PageRequest {
+	return PageRequest{PageRequest: httputil.PageRequest{Size: 1}}
+}
+
+func PageResp(entries []trash.Entry, page httputil.PageResponse) PageResponse {
+	return PageResponse{
+		PageResponse: page,
+		Data: slice.Map(entries, func(e trash.Entry) Entry {
+			return Entry{
+				ID:        e.ID,
+				Type:      e.Type,
+				BoardID:   e.BoardID,
+				Name:      e.Name,
+				DeletedAt: e.DeletedAt,
+				ExpiresAt: e.ExpiresAt,
+			}
+		}),
+	}
+}
@@ -2,35 +2,43 @@ package users
 
 import (
 	"context"
+	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/go-funcards/funapi/internal/gin/binding"
 	"github.com/go-funcards/funapi/internal/gin/httputil"
 	"github.com/go-funcards/funapi/internal/handlers"
 	"github.com/go-funcards/funapi/internal/handlers/v1/clientutil"
+	"github.com/go-funcards/funapi/internal/handlers/v1/session"
+	"github.com/go-funcards/funapi/internal/tokenstore"
 	"github.com/go-funcards/funapi/proto/user_service/v1"
 	"go.uber.org/zap"
 	"net/http"
+	"strings"
 )
 
 var _ handlers.Handler = (*Handler)(nil)
 
 type Handler struct {
-	UserService v1.UserClient
-	IsGranted   httputil.IsGrantedFn
-	Log         *zap.Logger
+	UserService  v1.UserClient
+	TokenStorage *tokenstore.Storage
+	Session      *session.Handler
+	IsGranted    httputil.IsGrantedFn
+	Log          *zap.Logger
 }
 
 func (h *Handler) Register(rg *gin.RouterGroup) {
 	g := rg.Group("/users")
 	{
 		g.GET("/me", h.me)
+		g.GET("/me/sessions", h.sessions)
+		g.DELETE("/me/sessions/:session_id", h.deleteSession)
 		g.PATCH("/:user_id", h.update)
 	}
 }
 
 // @Summary Update User
 // @Tags Users
-// @Description Update User Info
+// @Description Update User Info. A changed email is unverified until the token sent to it is confirmed.
 // @ModuleID updateUser
 // @Accept json
 // @Produce json
@@ -41,7 +49,7 @@ func (h *Handler) Register(rg *gin.RouterGroup) {
 // @Router /users/{user_id} [patch]
 // @Security BearerAuth
 func (h *Handler) update(c *gin.Context) {
-	ctx := context.TODO()
+	ctx := httputil.GRPCAuth(c)
 
 	h.Log.Debug("user handler::create bind")
 	var dto UpdateUserDTO
@@ -54,11 +62,51 @@ func (h *Handler) update(c *gin.Context) {
 		return
 	}
 
-	if _, err := h.UserService.UpdateUser(ctx, dto.toUpdate()); err != nil {
+	emailChanged := false
+	if len(dto.Email) > 0 {
+		h.Log.Debug("user handler::update call gRPC /UserClient/GetUsers")
+		user, err := clientutil.GetUser(ctx, h.UserService, dto.UserID)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		emailChanged = !strings.EqualFold(strings.TrimSpace(user.GetEmail()), strings.TrimSpace(dto.Email))
+	}
+
+	saga := handlers.NewSaga(h.Log)
+
+	// the new email must never be taken for verified, so the user is unverified before it is set
+	// and its previous state is restored when the update fails, e.g. when the email is taken
+	if emailChanged {
+		var previous tokenstore.Snapshot
+		if err := saga.Do(ctx, "MarkUnverified", func(ctx context.Context) (err error) {
+			if previous, err = h.Session.Verifications.Snapshot(ctx, dto.UserID); err != nil {
+				return err
+			}
+			return h.Session.Verifications.MarkUnverified(ctx, dto.UserID)
+		}, func(ctx context.Context) error {
+			return h.Session.Verifications.Restore(ctx, dto.UserID, previous)
+		}); err != nil {
+			_ = c.Error(err)
+			return
+		}
+	}
+
+	h.Log.Debug("user handler::update call gRPC /UserClient/UpdateUser")
+	if err := saga.Do(ctx, "UpdateUser", func(ctx context.Context) error {
+		_, err := h.UserService.UpdateUser(ctx, dto.toUpdate())
+		return err
+	}, nil); err != nil {
 		_ = c.Error(err)
 		return
 	}
 
+	if emailChanged {
+		if err := h.Session.SendVerification(ctx, dto.UserID, dto.Email); err != nil {
+			h.Log.Error("user handler::update send verification", zap.Error(err))
+		}
+	}
+
 	c.Status(http.StatusNoContent)
 }
 
@@ -72,7 +120,7 @@ func (h *Handler) update(c *gin.Context) {
 // @Router /users/me [get]
 // @Security BearerAuth
 func (h *Handler) me(c *gin.Context) {
-	ctx := context.TODO()
+	ctx := httputil.GRPCAuth(c)
 
 	h.Log.Debug("user handler::me")
 	user, err := clientutil.GetUser(ctx, h.UserService, httputil.GetUserID(c))
@@ -83,3 +131,53 @@ func (h *Handler) me(c *gin.Context) {
 
 	c.JSON(http.StatusOK, CreateUser(user))
 }
+
+// @Summary Authenticated User Sessions
+// @Tags Users
+// @Description Return active sessions of authenticated user
+// @ModuleID listSessions
+// @Produce json
+// @Success 200 {object} users.SessionsResponse
+// @Failure 400,401,500 {object} httputil.APIError
+// @Router /users/me/sessions [get]
+// @Security BearerAuth
+func (h *Handler) sessions(c *gin.Context) {
+	ctx, userID := httputil.CtxWithUserID(c)
+
+	h.Log.Debug("user handler::sessions")
+	sessions, err := h.TokenStorage.Sessions(ctx, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	httputil.NoCache(c)
+	c.JSON(http.StatusOK, SessionsResp(sessions))
+}
+
+// @Summary Delete Authenticated User Session
+// @Tags Users
+// @Description Revoke a session of authenticated user, its refresh token and access tokens
+// @ModuleID deleteSession
+// @Param session_id path string true "Session ID" format(uuid)
+// @Success 204
+// @Failure 400,401,404,500 {object} httputil.APIError
+// @Router /users/me/sessions/{session_id} [delete]
+// @Security BearerAuth
+func (h *Handler) deleteSession(c *gin.Context) {
+	h.Log.Debug("user handler::deleteSession bind")
+	var dto DeleteSessionDTO
+	if !binding.BindCtx(c, &dto) || !binding.BindUriAndValidate(c, &dto) {
+		return
+	}
+
+	if err := h.TokenStorage.RevokeSession(httputil.GRPCAuth(c), dto.UserID, dto.SessionID); err != nil {
+		if errors.Is(err, tokenstore.ErrNotFound) {
+			err = httputil.ErrNotFound
+		}
+		_ = c.Error(err)
+		return
+	}
+
+	httputil.NoContent(c)
+}
@@ -1,7 +1,9 @@
 package users
 
 import (
+	"github.com/go-funcards/funapi/internal/tokenstore"
 	"github.com/go-funcards/funapi/proto/user_service/v1"
+	"github.com/go-funcards/slice"
 	"time"
 )
 
@@ -39,3 +41,34 @@ func CreateUser(response *v1.UserResponse) User {
 		CreatedAt: response.GetCreatedAt().AsTime(),
 	}
 }
+
+type Session struct {
+	SessionID   string    `json:"session_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	RefreshedAt time.Time `json:"refreshed_at"`
+	UserAgent   string    `json:"user_agent"`
+	IP          string    `json:"ip"`
+}
+
+type SessionsResponse struct {
+	Data []Session `json:"data"`
+}
+
+type DeleteSessionDTO struct {
+	UserID    string `json:"-" ctx:"user_id" validate:"required,uuid4"`
+	SessionID string `json:"-" uri:"session_id" validate:"required,uuid4"`
+}
+
+func SessionsResp(sessions []tokenstore.Session) SessionsResponse {
+	return SessionsResponse{
+		Data: slice.Map(sessions, func(s tokenstore.Session) Session {
+			return Session{
+				SessionID:   s.SessionID,
+				CreatedAt:   s.CreatedAt,
+				RefreshedAt: s.RefreshedAt,
+				UserAgent:   s.UserAgent,
+				IP:          s.IP,
+			}
+		}),
+	}
+}
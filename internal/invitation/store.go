@@ -0,0 +1,185 @@
+package invitation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/go-funcards/slice"
+	"github.com/go-redis/redis/v8"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrNotFound = errors.New("invitation not found")
+
+type Config struct {
+	// TTL is how long an invitation may be accepted
+	TTL time.Duration `yaml:"ttl" env:"TTL" env-default:"168h"`
+	// URL of the client page listing invitations of the user, sent in the invitation email
+	URL string `yaml:"url" env:"URL"`
+}
+
+// put replaces the pending invitation of the board for the email atomically, so that
+// concurrent invitations do not leave one of them orphaned.
+var put = redis.NewScript(`
+local previous = redis.call('GET', KEYS[1])
+if previous then
+	redis.call('DEL', ARGV[5] .. previous)
+	redis.call('ZREM', KEYS[3], previous)
+end
+redis.call('SET', KEYS[2], ARGV[2], 'PX', ARGV[3])
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[3])
+redis.call('ZADD', KEYS[3], ARGV[4], ARGV[1])
+redis.call('PEXPIRE', KEYS[3], ARGV[3])
+return 1
+`)
+
+// Invitation invites the user with the email to become a member of the board with the roles.
+type Invitation struct {
+	ID        string    `json:"id"`
+	BoardID   string    `json:"board_id"`
+	BoardName string    `json:"board_name"`
+	Email     string    `json:"email"`
+	Roles     []string  `json:"roles"`
+	InvitedBy string    `json:"invited_by"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Store keeps invitations until they are accepted, declined or expire. Invitations are
+// indexed by email, a board has at most one pending invitation per email.
+type Store struct {
+	Redis *redis.Client
+	TTL   time.Duration
+}
+
+// Put saves the invitation, replacing the pending invitation of the board for the same email.
+func (s *Store) Put(ctx context.Context, inv Invitation) (Invitation, error) {
+	inv.Email = NormalizeEmail(inv.Email)
+	inv.CreatedAt = time.Now().UTC()
+	inv.ExpiresAt = inv.CreatedAt.Add(s.TTL)
+
+	value, err := json.Marshal(inv)
+	if err != nil {
+		return inv, err
+	}
+
+	err = put.Run(ctx, s.Redis,
+		[]string{boardKey(inv.BoardID, inv.Email), invitationKey(inv.ID), emailKey(inv.Email)},
+		inv.ID, value, s.TTL.Milliseconds(), inv.ExpiresAt.Unix(), invitationKey(""),
+	).Err()
+	return inv, err
+}
+
+func (s *Store) Get(ctx context.Context, id string) (Invitation, error) {
+	var inv Invitation
+	data, err := s.Redis.Get(ctx, invitationKey(id)).Bytes()
+	if err == redis.Nil {
+		return inv, ErrNotFound
+	}
+	if err != nil {
+		return inv, err
+	}
+	return inv, json.Unmarshal(data, &inv)
+}
+
+// List returns a page of pending invitations for the email, the most recent first.
+func (s *Store) List(ctx context.Context, email string, index uint64, size uint32) ([]Invitation, uint64, error) {
+	key := emailKey(NormalizeEmail(email))
+
+	if err := s.Redis.ZRemRangeByScore(ctx, key, "-inf", strconv.FormatInt(time.Now().Unix(), 10)).Err(); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.Redis.ZCard(ctx, key).Result()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	start := int64(index) * int64(size)
+	ids, err := s.Redis.ZRevRange(ctx, key, start, start+int64(size)-1).Result()
+	if err != nil || len(ids) == 0 {
+		return nil, uint64(total), err
+	}
+
+	values, err := s.Redis.MGet(ctx, slice.Map(ids, invitationKey)...).Result()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	invitations := make([]Invitation, 0, len(values))
+	for _, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+		var inv Invitation
+		if err = json.Unmarshal([]byte(data), &inv); err != nil {
+			return nil, 0, err
+		}
+		invitations = append(invitations, inv)
+	}
+	return invitations, uint64(total), nil
+}
+
+// Claim removes the invitation. Only one of concurrent accepts and declines claims it.
+func (s *Store) Claim(ctx context.Context, inv Invitation) (bool, error) {
+	n, err := s.Redis.Del(ctx, invitationKey(inv.ID)).Result()
+	if err != nil || n == 0 {
+		return false, err
+	}
+	_, err = s.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRem(ctx, emailKey(inv.Email), inv.ID)
+		pipe.Del(ctx, boardKey(inv.BoardID, inv.Email))
+		return nil
+	})
+	return true, err
+}
+
+// Unclaim puts the invitation back until it expires, e.g. when accepting it failed.
+func (s *Store) Unclaim(ctx context.Context, inv Invitation) error {
+	ttl := time.Until(inv.ExpiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+
+	value, err := json.Marshal(inv)
+	if err != nil {
+		return err
+	}
+
+	// the index must outlive the invitation, it may expire sooner or be gone when it was the last one
+	current, err := s.Redis.TTL(ctx, emailKey(inv.Email)).Result()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, invitationKey(inv.ID), value, ttl)
+		pipe.SetNX(ctx, boardKey(inv.BoardID, inv.Email), inv.ID, ttl)
+		pipe.ZAdd(ctx, emailKey(inv.Email), &redis.Z{Score: float64(inv.ExpiresAt.Unix()), Member: inv.ID})
+		if current < ttl {
+			pipe.Expire(ctx, emailKey(inv.Email), ttl)
+		}
+		return nil
+	})
+	return err
+}
+
+// NormalizeEmail returns the email invitations are indexed by.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func invitationKey(id string) string {
+	return "invitation:" + id
+}
+
+func emailKey(email string) string {
+	return "invitations_email:" + email
+}
+
+func boardKey(boardID, email string) string {
+	return "invitation_board:" + boardID + ":" + email
+}
@@ -0,0 +1,218 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/go-funcards/funapi/internal/search"
+	v1Card "github.com/go-funcards/funapi/proto/card_service/v1"
+	v1Category "github.com/go-funcards/funapi/proto/category_service/v1"
+	v1Tag "github.com/go-funcards/funapi/proto/tag_service/v1"
+	"github.com/go-redis/redis/v8"
+	"go.uber.org/zap"
+	"time"
+)
+
+const (
+	StatusScheduled = "scheduled"
+	StatusRunning   = "running"
+	StatusCompleted = "completed"
+	StatusFailed    = "failed"
+)
+
+var ErrNotFound = errors.New("job not found")
+
+type DeletionConfig struct {
+	// PageSize is the number of entities fetched and deleted per round
+	PageSize uint32 `yaml:"page_size" env:"PAGE_SIZE" env-default:"100"`
+	// TTL is how long the progress of a finished job is reported
+	TTL time.Duration `yaml:"ttl" env:"TTL" env-default:"24h"`
+}
+
+// Deletion is the progress of the cleanup of a deleted board. The cleanup is scheduled when
+// the board is deleted and starts when its trash entry is purged, not before ScheduledAt.
+type Deletion struct {
+	BoardID     string     `json:"board_id"`
+	RequestedBy string     `json:"requested_by"`
+	Status      string     `json:"status" enums:"scheduled,running,completed,failed"`
+	Cards       uint64     `json:"cards"`
+	Categories  uint64     `json:"categories"`
+	Tags        uint64     `json:"tags"`
+	Error       string     `json:"error,omitempty"`
+	ScheduledAt time.Time  `json:"scheduled_at"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+}
+
+// BoardDeletion deletes cards, categories and tags of purged boards.
+// The progress is stored in Redis, so it may be reported by any gateway instance.
+type BoardDeletion struct {
+	Redis           *redis.Client
+	CardService     v1Card.CardClient
+	CategoryService v1Category.CategoryClient
+	TagService      v1Tag.TagClient
+	Search          *search.Index
+	Config          DeletionConfig
+	Log             *zap.Logger
+}
+
+// Schedule reports the cleanup of the deleted board to the user who deleted it until it runs.
+func (j *BoardDeletion) Schedule(ctx context.Context, boardID, userID string, at time.Time) error {
+	return j.save(ctx, Deletion{
+		BoardID:     boardID,
+		RequestedBy: userID,
+		Status:      StatusScheduled,
+		ScheduledAt: at.UTC(),
+	})
+}
+
+// Cancel deletes the scheduled cleanup, e.g. when the board is restored.
+func (j *BoardDeletion) Cancel(ctx context.Context, boardID string) error {
+	return j.Redis.Del(ctx, deletionKey(boardID)).Err()
+}
+
+// Run deletes cards, categories and tags of the board. The progress is reported to the user who deleted it.
+func (j *BoardDeletion) Run(ctx context.Context, boardID, userID string) error {
+	now := time.Now().UTC()
+	d := Deletion{
+		BoardID:     boardID,
+		RequestedBy: userID,
+		Status:      StatusRunning,
+		ScheduledAt: now,
+		StartedAt:   &now,
+	}
+	if scheduled, err := j.Get(ctx, boardID); err == nil {
+		d.ScheduledAt = scheduled.ScheduledAt
+	}
+	if err := j.save(ctx, d); err != nil {
+		return err
+	}
+	return j.run(ctx, d)
+}
+
+// Get returns the progress of the cleanup of the board.
+func (j *BoardDeletion) Get(ctx context.Context, boardID string) (Deletion, error) {
+	var d Deletion
+	data, err := j.Redis.Get(ctx, deletionKey(boardID)).Bytes()
+	if err == redis.Nil {
+		return d, ErrNotFound
+	}
+	if err != nil {
+		return d, err
+	}
+	return d, json.Unmarshal(data, &d)
+}
+
+func (j *BoardDeletion) run(ctx context.Context, d Deletion) error {
+	log := j.Log.With(zap.String("board_id", d.BoardID))
+	log.Debug("board deletion::run started")
+
+	err := j.deleteCards(ctx, &d)
+	if err == nil {
+		err = j.deleteCategories(ctx, &d)
+	}
+	if err == nil {
+		err = j.deleteTags(ctx, &d)
+	}
+	if err == nil {
+		err = j.Search.RemoveBoard(ctx, d.BoardID)
+	}
+
+	now := time.Now().UTC()
+	d.FinishedAt = &now
+	d.Status = StatusCompleted
+	if err != nil {
+		log.Error("board deletion::run failed", zap.Error(err))
+		d.Status = StatusFailed
+		d.Error = err.Error()
+	}
+
+	if saveErr := j.save(ctx, d); saveErr != nil {
+		log.Error("board deletion::run save progress", zap.Error(saveErr))
+	}
+	log.Debug("board deletion::run finished", zap.String("status", d.Status))
+
+	return err
+}
+
+// Entities are deleted from the first page until the board has none left,
+// so that deletions do not shift the following pages.
+func (j *BoardDeletion) deleteCards(ctx context.Context, d *Deletion) error {
+	for {
+		response, err := j.CardService.GetCards(ctx, &v1Card.CardsRequest{
+			PageSize: j.Config.PageSize,
+			BoardIds: []string{d.BoardID},
+		})
+		if err != nil || len(response.GetCards()) == 0 {
+			return err
+		}
+		for _, card := range response.GetCards() {
+			if _, err = j.CardService.DeleteCard(ctx, &v1Card.DeleteCardRequest{CardId: card.GetCardId()}); err != nil {
+				return err
+			}
+			d.Cards++
+		}
+		if err = j.save(ctx, *d); err != nil {
+			return err
+		}
+	}
+}
+
+func (j *BoardDeletion) deleteCategories(ctx context.Context, d *Deletion) error {
+	for {
+		response, err := j.CategoryService.GetCategories(ctx, &v1Category.CategoriesRequest{
+			PageSize: j.Config.PageSize,
+			BoardIds: []string{d.BoardID},
+		})
+		if err != nil || len(response.GetCategories()) == 0 {
+			return err
+		}
+		for _, category := range response.GetCategories() {
+			if _, err = j.CategoryService.DeleteCategory(ctx, &v1Category.DeleteCategoryRequest{CategoryId: category.GetCategoryId()}); err != nil {
+				return err
+			}
+			d.Categories++
+		}
+		if err = j.save(ctx, *d); err != nil {
+			return err
+		}
+	}
+}
+
+func (j *BoardDeletion) deleteTags(ctx context.Context, d *Deletion) error {
+	for {
+		response, err := j.TagService.GetTags(ctx, &v1Tag.TagsRequest{
+			PageSize: j.Config.PageSize,
+			BoardIds: []string{d.BoardID},
+		})
+		if err != nil || len(response.GetTags()) == 0 {
+			return err
+		}
+		for _, tag := range response.GetTags() {
+			if _, err = j.TagService.DeleteTag(ctx, &v1Tag.DeleteTagRequest{TagId: tag.GetTagId()}); err != nil {
+				return err
+			}
+			d.Tags++
+		}
+		if err = j.save(ctx, *d); err != nil {
+			return err
+		}
+	}
+}
+
+func (j *BoardDeletion) save(ctx context.Context, d Deletion) error {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	// a scheduled cleanup is reported until it runs
+	ttl := j.Config.TTL
+	if d.Status == StatusScheduled {
+		ttl += time.Until(d.ScheduledAt)
+	}
+	return j.Redis.Set(ctx, deletionKey(d.BoardID), data, ttl).Err()
+}
+
+func deletionKey(boardID string) string {
+	return "board_deletion:" + boardID
+}
@@ -0,0 +1,91 @@
+package jobs
+
+import (
+	"context"
+	"github.com/go-funcards/funapi/internal/trash"
+	"go.uber.org/zap"
+	"time"
+)
+
+const purgeBatch = 100
+
+// Purge removes trash entries whose retention expired. Deleted entities are already
+// gone from their services, except for the contents of boards, which are deleted
+// only now, so that a restored board gets its cards, categories and tags back.
+type Purge struct {
+	Trash         *trash.Store
+	BoardDeletion *BoardDeletion
+	Interval      time.Duration
+	Log           *zap.Logger
+}
+
+// Run purges expired entries every interval until the context is done.
+func (p *Purge) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.Interval)
+	defer ticker.Stop()
+
+	for {
+		p.purge(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *Purge) purge(ctx context.Context) {
+	if err := p.Trash.Reclaim(ctx, time.Now(), purgeBatch); err != nil {
+		p.Log.Error("purge::reclaim", zap.Error(err))
+	}
+
+	ids, err := p.Trash.Expired(ctx, time.Now(), purgeBatch)
+	if err != nil {
+		p.Log.Error("purge::expired", zap.Error(err))
+		return
+	}
+
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			return
+		}
+
+		log := p.Log.With(zap.String("id", id))
+
+		claimed, err := p.Trash.Claim(ctx, id)
+		if err != nil {
+			log.Error("purge::claim", zap.Error(err))
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		e, err := p.Trash.Get(ctx, id)
+		if err == trash.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			log.Error("purge::get", zap.Error(err))
+			continue
+		}
+
+		if e.Type == trash.TypeBoard {
+			if err = p.BoardDeletion.Run(ctx, e.ID, e.DeletedBy); err != nil {
+				log.Error("purge::board deletion", zap.Error(err))
+				if err = p.Trash.Unclaim(ctx, e); err != nil {
+					log.Error("purge::unclaim", zap.Error(err))
+				}
+				continue
+			}
+		}
+
+		// a failed removal is purged again once the lease of the claim expires
+		if err = p.Trash.Remove(ctx, e); err != nil {
+			log.Error("purge::remove", zap.Error(err))
+			continue
+		}
+		log.Debug("purge::purged", zap.String("type", e.Type))
+	}
+}
@@ -0,0 +1,22 @@
+package notify
+
+import (
+	"context"
+	"go.uber.org/zap"
+)
+
+var _ Notifier = (*LogNotifier)(nil)
+
+// LogNotifier writes notifications to the application log, it is meant for local development.
+type LogNotifier struct {
+	Log *zap.Logger
+}
+
+func (n *LogNotifier) Notify(_ context.Context, msg Message) error {
+	n.Log.Info("notification",
+		zap.String("to", msg.To),
+		zap.String("subject", msg.Subject),
+		zap.String("body", msg.Body),
+	)
+	return nil
+}
@@ -0,0 +1,40 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+)
+
+// Message is a notification addressed to a user by email.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
+
+type Config struct {
+	Driver string     `yaml:"driver" env:"DRIVER" env-default:"log"`
+	File   string     `yaml:"file" env:"FILE" env-default:"notifications.log"`
+	SMTP   SMTPConfig `yaml:"smtp" env-prefix:"SMTP_"`
+}
+
+func (cfg Config) Notifier(log *zap.Logger) (Notifier, error) {
+	switch cfg.Driver {
+	case "log":
+		return &LogNotifier{Log: log}, nil
+	case "file":
+		return &FileNotifier{Path: cfg.File}, nil
+	case "smtp":
+		if len(cfg.SMTP.Host) == 0 || len(cfg.SMTP.From) == 0 {
+			return nil, errors.New("smtp notifier requires host and from address")
+		}
+		return &SMTPNotifier{Config: cfg.SMTP}, nil
+	}
+	return nil, fmt.Errorf("unknown notifier driver %q", cfg.Driver)
+}
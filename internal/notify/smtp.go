@@ -0,0 +1,45 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var _ Notifier = (*SMTPNotifier)(nil)
+
+type SMTPConfig struct {
+	Host     string `yaml:"host" env:"HOST"`
+	Port     uint16 `yaml:"port" env:"PORT" env-default:"587"`
+	Username string `yaml:"username" env:"USERNAME"`
+	Password string `yaml:"password" env:"PASSWORD"`
+	From     string `yaml:"from" env:"FROM"`
+}
+
+// SMTPNotifier sends notifications as plain text emails.
+type SMTPNotifier struct {
+	Config SMTPConfig
+}
+
+func (n *SMTPNotifier) Notify(_ context.Context, msg Message) error {
+	var auth smtp.Auth
+	if len(n.Config.Username) > 0 {
+		auth = smtp.PlainAuth("", n.Config.Username, n.Config.Password, n.Config.Host)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", n.Config.From)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+
+	addr := net.JoinHostPort(n.Config.Host, strconv.Itoa(int(n.Config.Port)))
+	return smtp.SendMail(addr, auth, n.Config.From, []string{msg.To}, []byte(b.String()))
+}
@@ -0,0 +1,37 @@
+package search
+
+import (
+	"context"
+	"github.com/go-funcards/funapi/internal/handlers/v1/clientutil"
+	v1Card "github.com/go-funcards/funapi/proto/card_service/v1"
+	v1Category "github.com/go-funcards/funapi/proto/category_service/v1"
+	v1Tag "github.com/go-funcards/funapi/proto/tag_service/v1"
+	"github.com/go-funcards/slice"
+)
+
+// Sources are the services owning indexed documents.
+type Sources struct {
+	CategoryService v1Category.CategoryClient
+	CardService     v1Card.CardClient
+	TagService      v1Tag.TagClient
+}
+
+// BoardDocuments returns documents of all categories, cards and tags of the board.
+func (s Sources) BoardDocuments(ctx context.Context, boardID string) ([]Document, error) {
+	categories, err := clientutil.GetAllCategories(ctx, s.CategoryService, &v1Category.CategoriesRequest{BoardIds: []string{boardID}})
+	if err != nil {
+		return nil, err
+	}
+	cards, err := clientutil.GetAllCards(ctx, s.CardService, &v1Card.CardsRequest{BoardIds: []string{boardID}})
+	if err != nil {
+		return nil, err
+	}
+	tags, err := clientutil.GetAllTags(ctx, s.TagService, &v1Tag.TagsRequest{BoardIds: []string{boardID}})
+	if err != nil {
+		return nil, err
+	}
+
+	docs := slice.Map(categories, CategoryDocument)
+	docs = append(docs, slice.Map(cards, CardDocument)...)
+	return append(docs, slice.Map(tags, TagDocument)...), nil
+}
@@ -0,0 +1,37 @@
+package search
+
+import (
+	v1Card "github.com/go-funcards/funapi/proto/card_service/v1"
+	v1Category "github.com/go-funcards/funapi/proto/category_service/v1"
+	v1Tag "github.com/go-funcards/funapi/proto/tag_service/v1"
+)
+
+func CardDocument(card *v1Card.CardsResponse_Card) Document {
+	return Document{
+		ID:      card.GetCardId(),
+		Type:    TypeCard,
+		BoardID: card.GetBoardId(),
+		Owner:   card.GetOwnerId(),
+		Name:    card.GetName(),
+		Content: card.GetContent(),
+	}
+}
+
+func CategoryDocument(category *v1Category.CategoriesResponse_Category) Document {
+	return Document{
+		ID:      category.GetCategoryId(),
+		Type:    TypeCategory,
+		BoardID: category.GetBoardId(),
+		Owner:   category.GetOwnerId(),
+		Name:    category.GetName(),
+	}
+}
+
+func TagDocument(tag *v1Tag.TagsResponse_Tag) Document {
+	return Document{
+		ID:      tag.GetTagId(),
+		Type:    TypeTag,
+		BoardID: tag.GetBoardId(),
+		Name:    tag.GetName(),
+	}
+}
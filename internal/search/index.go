@@ -0,0 +1,258 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-funcards/slice"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"strings"
+	"time"
+)
+
+const (
+	TypeCard     = "card"
+	TypeCategory = "category"
+	TypeTag      = "tag"
+
+	nameWeight    = 3
+	contentWeight = 1
+
+	tmpTTL = time.Minute
+)
+
+// Document is an indexed card, category or tag.
+type Document struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	BoardID string `json:"board_id"`
+	// Owner of the card or category, READ is checked on the document as a child of its board
+	Owner   string `json:"owner,omitempty"`
+	Name    string `json:"name"`
+	Content string `json:"content,omitempty"`
+	// Terms are kept to remove the document from the index
+	Terms []string `json:"terms"`
+}
+
+// Hit is a found document, Name and Content are highlighted.
+type Hit struct {
+	Document
+	Score float64
+}
+
+// Index is an inverted index of cards, categories and tags stored in Redis and shared by
+// gateway instances. Terms are indexed per board, so that a search reads only accessible boards.
+// It is fed by handlers on writes. Feeding is best effort, failures are logged and repaired
+// by rebuilding the index.
+type Index struct {
+	Redis *redis.Client
+	Log   *zap.Logger
+}
+
+// Add indexes the documents, replacing previously indexed versions.
+func (idx *Index) Add(ctx context.Context, docs ...Document) {
+	for _, doc := range docs {
+		if err := idx.add(ctx, doc); err != nil {
+			idx.Log.Error("search index::add", zap.String("id", doc.ID), zap.Error(err))
+		}
+	}
+}
+
+// Remove removes the documents from the index.
+func (idx *Index) Remove(ctx context.Context, ids ...string) {
+	for _, id := range ids {
+		if err := idx.remove(ctx, id); err != nil {
+			idx.Log.Error("search index::remove", zap.String("id", id), zap.Error(err))
+		}
+	}
+}
+
+// RemoveBoard removes all documents of the board from the index.
+func (idx *Index) RemoveBoard(ctx context.Context, boardID string) error {
+	ids, err := idx.Redis.SMembers(ctx, boardKey(boardID)).Result()
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err = idx.remove(ctx, id); err != nil {
+			return err
+		}
+	}
+	return idx.Redis.Del(ctx, boardKey(boardID)).Err()
+}
+
+// ReplaceBoard indexes the documents of the board and removes its other documents. Documents
+// are replaced in place, so that the board stays searchable while it is reindexed.
+func (idx *Index) ReplaceBoard(ctx context.Context, boardID string, docs []Document) error {
+	indexed, err := idx.Redis.SMembers(ctx, boardKey(boardID)).Result()
+	if err != nil {
+		return err
+	}
+
+	keep := make(map[string]bool, len(docs))
+	for _, doc := range docs {
+		if err = idx.add(ctx, doc); err != nil {
+			return err
+		}
+		keep[doc.ID] = true
+	}
+
+	for _, id := range indexed {
+		if keep[id] {
+			continue
+		}
+		if err = idx.remove(ctx, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Boards returns IDs of boards with indexed documents.
+func (idx *Index) Boards(ctx context.Context) ([]string, error) {
+	var ids []string
+	iter := idx.Redis.Scan(ctx, 0, boardKey("*"), 1000).Iterator()
+	for iter.Next(ctx) {
+		ids = append(ids, strings.TrimPrefix(iter.Val(), boardKey("")))
+	}
+	return ids, iter.Err()
+}
+
+// Search returns documents of the boards containing all terms of the query,
+// the best matches first, and the total number of them.
+func (idx *Index) Search(ctx context.Context, boardIDs []string, query string, offset, limit int64) ([]Hit, uint64, error) {
+	terms := Terms(query)
+	if len(terms) == 0 || len(boardIDs) == 0 {
+		return nil, 0, nil
+	}
+
+	tmp := "search_tmp:" + uuid.NewString()
+	unions := make([]string, len(terms))
+
+	var (
+		total *redis.IntCmd
+		found *redis.ZSliceCmd
+	)
+	_, err := idx.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, term := range terms {
+			unions[i] = tmp + ":" + term
+			pipe.ZUnionStore(ctx, unions[i], &redis.ZStore{Keys: slice.Map(boardIDs, func(boardID string) string {
+				return termKey(boardID, term)
+			})})
+			pipe.Expire(ctx, unions[i], tmpTTL)
+		}
+		pipe.ZInterStore(ctx, tmp, &redis.ZStore{Keys: unions, Aggregate: "SUM"})
+		pipe.Expire(ctx, tmp, tmpTTL)
+		total = pipe.ZCard(ctx, tmp)
+		found = pipe.ZRevRangeWithScores(ctx, tmp, offset, offset+limit-1)
+		pipe.Del(ctx, append(unions, tmp)...)
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	scores := found.Val()
+	if len(scores) == 0 {
+		return nil, uint64(total.Val()), nil
+	}
+
+	values, err := idx.Redis.MGet(ctx, slice.Map(scores, func(z redis.Z) string {
+		return docKey(z.Member.(string))
+	})...).Result()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	matched := make(map[string]bool, len(terms))
+	for _, term := range terms {
+		matched[term] = true
+	}
+
+	hits := make([]Hit, 0, len(values))
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+		var doc Document
+		if err = json.Unmarshal([]byte(data), &doc); err != nil {
+			return nil, 0, err
+		}
+		doc.Name = highlight(doc.Name, matched, 0)
+		doc.Content = highlight(doc.Content, matched, snippetLen)
+		hits = append(hits, Hit{Document: doc, Score: scores[i].Score})
+	}
+	return hits, uint64(total.Val()), nil
+}
+
+func (idx *Index) add(ctx context.Context, doc Document) error {
+	if err := idx.remove(ctx, doc.ID); err != nil {
+		return err
+	}
+
+	weights := make(map[string]float64)
+	for _, t := range tokens(doc.Name) {
+		weights[t.term] += nameWeight
+	}
+	for _, t := range tokens(doc.Content) {
+		weights[t.term] += contentWeight
+	}
+	doc.Terms = make([]string, 0, len(weights))
+	for term := range weights {
+		doc.Terms = append(doc.Terms, term)
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	_, err = idx.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, docKey(doc.ID), data, 0)
+		pipe.SAdd(ctx, boardKey(doc.BoardID), doc.ID)
+		for term, weight := range weights {
+			pipe.ZAdd(ctx, termKey(doc.BoardID, term), &redis.Z{Score: weight, Member: doc.ID})
+		}
+		return nil
+	})
+	return err
+}
+
+func (idx *Index) remove(ctx context.Context, id string) error {
+	data, err := idx.Redis.Get(ctx, docKey(id)).Bytes()
+	if err == redis.Nil {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var doc Document
+	if err = json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+
+	_, err = idx.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, term := range doc.Terms {
+			pipe.ZRem(ctx, termKey(doc.BoardID, term), doc.ID)
+		}
+		pipe.SRem(ctx, boardKey(doc.BoardID), doc.ID)
+		pipe.Del(ctx, docKey(doc.ID))
+		return nil
+	})
+	return err
+}
+
+func docKey(id string) string {
+	return "search_doc:" + id
+}
+
+func boardKey(boardID string) string {
+	return "search_board:" + boardID
+}
+
+func termKey(boardID, term string) string {
+	return "search_term:" + boardID + ":" + term
+}
@@ -0,0 +1,113 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	minTermLen = 2
+	maxTermLen = 64
+	// snippetLen is the length in runes of highlighted content around the first match
+	snippetLen = 160
+)
+
+type token struct {
+	term       string
+	start, end int
+}
+
+// tokens splits text to lower case terms of letters and digits, with their byte offsets in text.
+func tokens(text string) []token {
+	var result []token
+	start := -1
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+		if n := utf8.RuneCountInString(text[start:end]); n >= minTermLen && n <= maxTermLen {
+			result = append(result, token{term: strings.ToLower(text[start:end]), start: start, end: end})
+		}
+		start = -1
+	}
+	for i, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		flush(i)
+	}
+	flush(len(text))
+	return result
+}
+
+// Terms returns distinct terms of the text.
+func Terms(text string) []string {
+	seen := make(map[string]bool)
+	var terms []string
+	for _, t := range tokens(text) {
+		if !seen[t.term] {
+			seen[t.term] = true
+			terms = append(terms, t.term)
+		}
+	}
+	return terms
+}
+
+// highlight escapes the text for HTML and wraps the terms in <em> tags. When limit is positive,
+// only a snippet of about limit runes around the first match is returned.
+func highlight(text string, terms map[string]bool, limit int) string {
+	var matches []token
+	for _, t := range tokens(text) {
+		if terms[t.term] {
+			matches = append(matches, t)
+		}
+	}
+
+	from, to := 0, len(text)
+	if limit > 0 && utf8.RuneCountInString(text) > limit {
+		from = 0
+		if len(matches) > 0 {
+			from = moveRunes(text, matches[0].start, -limit/4)
+		}
+		to = moveRunes(text, from, limit)
+	}
+
+	var sb strings.Builder
+	if from > 0 {
+		sb.WriteString("…")
+	}
+	pos := from
+	for _, m := range matches {
+		if m.start < from || m.end > to {
+			continue
+		}
+		sb.WriteString(html.EscapeString(text[pos:m.start]))
+		sb.WriteString("<em>")
+		sb.WriteString(html.EscapeString(text[m.start:m.end]))
+		sb.WriteString("</em>")
+		pos = m.end
+	}
+	sb.WriteString(html.EscapeString(text[pos:to]))
+	if to < len(text) {
+		sb.WriteString("…")
+	}
+	return sb.String()
+}
+
+// moveRunes returns the byte offset n runes after (or before when negative) the offset.
+func moveRunes(text string, offset, n int) int {
+	for ; n > 0 && offset < len(text); n-- {
+		_, size := utf8.DecodeRuneInString(text[offset:])
+		offset += size
+	}
+	for ; n < 0 && offset > 0; n++ {
+		_, size := utf8.DecodeLastRuneInString(text[:offset])
+		offset -= size
+	}
+	return offset
+}
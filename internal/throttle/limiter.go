@@ -0,0 +1,90 @@
+package throttle
+
+import (
+	"context"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"time"
+)
+
+// slidingWindow keeps a sorted set of request timestamps (ms) per key and admits
+// a request while fewer than limit requests happened within the window.
+var slidingWindow = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+
+local count = redis.call('ZCARD', KEYS[1])
+local allowed = 0
+if count < limit then
+	redis.call('ZADD', KEYS[1], now, ARGV[4])
+	count = count + 1
+	allowed = 1
+end
+redis.call('PEXPIRE', KEYS[1], window)
+
+local reset = window
+local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+if oldest[2] then
+	reset = tonumber(oldest[2]) + window - now
+end
+
+return {allowed, limit - count, reset}
+`)
+
+type Quota struct {
+	Limit  int64         `yaml:"limit" env:"LIMIT" env-default:"300"`
+	Window time.Duration `yaml:"window" env:"WINDOW" env-default:"1m"`
+}
+
+type RateLimitConfig struct {
+	Enable  bool             `yaml:"enable" env:"ENABLE" env-default:"true"`
+	Default Quota            `yaml:"default" env-prefix:"DEFAULT_"`
+	Groups  map[string]Quota `yaml:"groups"`
+}
+
+type Result struct {
+	Allowed   bool
+	Limit     int64
+	Remaining int64
+	Reset     time.Duration
+}
+
+// Limiter enforces request quotas of route groups. It fails open, so that an
+// unavailable Redis does not take the gateway down.
+type Limiter struct {
+	Redis  *redis.Client
+	Config RateLimitConfig
+	Log    *zap.Logger
+}
+
+func (l *Limiter) Quota(group string) Quota {
+	if quota, ok := l.Config.Groups[group]; ok {
+		return quota
+	}
+	return l.Config.Default
+}
+
+func (l *Limiter) Allow(ctx context.Context, group, key string) Result {
+	quota := l.Quota(group)
+	if !l.Config.Enable || quota.Limit <= 0 {
+		return Result{Allowed: true}
+	}
+
+	values, err := slidingWindow.Run(ctx, l.Redis, []string{"rate_limit:" + group + ":" + key},
+		time.Now().UnixMilli(), quota.Window.Milliseconds(), quota.Limit, uuid.NewString()).Int64Slice()
+	if err != nil || len(values) != 3 {
+		l.Log.Warn("rate limiter unavailable", zap.String("group", group), zap.Error(err))
+		return Result{Allowed: true}
+	}
+
+	return Result{
+		Allowed:   values[0] == 1,
+		Limit:     quota.Limit,
+		Remaining: values[1],
+		Reset:     time.Duration(values[2]) * time.Millisecond,
+	}
+}
@@ -0,0 +1,118 @@
+package throttle
+
+import (
+	"context"
+	"github.com/go-redis/redis/v8"
+	"strings"
+	"time"
+)
+
+type LoginConfig struct {
+	MaxEmailAttempts int64         `yaml:"max_email_attempts" env:"MAX_EMAIL_ATTEMPTS" env-default:"5"`
+	MaxIPAttempts    int64         `yaml:"max_ip_attempts" env:"MAX_IP_ATTEMPTS" env-default:"20"`
+	Window           time.Duration `yaml:"window" env:"WINDOW" env-default:"15m"`
+	Lockout          time.Duration `yaml:"lockout" env:"LOCKOUT" env-default:"15m"`
+	Delay            time.Duration `yaml:"delay" env:"DELAY" env-default:"250ms"`
+	MaxDelay         time.Duration `yaml:"max_delay" env:"MAX_DELAY" env-default:"4s"`
+}
+
+// Login counts failed login attempts per email and per client IP within a window.
+// Every failure delays the response twice as long as the previous one, and once
+// the limit is reached the email or IP is locked out.
+type Login struct {
+	Redis  *redis.Client
+	Config LoginConfig
+}
+
+// Locked returns for how long the email or IP is still locked out.
+func (l *Login) Locked(ctx context.Context, email, ip string) (time.Duration, error) {
+	var retryAfter time.Duration
+	for _, key := range []string{lockKey("email", email), lockKey("ip", ip)} {
+		ttl, err := l.Redis.PTTL(ctx, key).Result()
+		if err != nil {
+			return 0, err
+		}
+		if ttl > retryAfter {
+			retryAfter = ttl
+		}
+	}
+	return retryAfter, nil
+}
+
+// Failed records a failed attempt. It returns the delay to apply before responding,
+// or for how long the email or IP is locked out when the limit was reached.
+func (l *Login) Failed(ctx context.Context, email, ip string) (delay, retryAfter time.Duration, err error) {
+	emailFailures, err := l.incr(ctx, failuresKey("email", email))
+	if err != nil {
+		return 0, 0, err
+	}
+	ipFailures, err := l.incr(ctx, failuresKey("ip", ip))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if emailFailures >= l.Config.MaxEmailAttempts {
+		if err = l.lock(ctx, "email", email); err != nil {
+			return 0, 0, err
+		}
+		retryAfter = l.Config.Lockout
+	}
+	if ipFailures >= l.Config.MaxIPAttempts {
+		if err = l.lock(ctx, "ip", ip); err != nil {
+			return 0, 0, err
+		}
+		retryAfter = l.Config.Lockout
+	}
+	if retryAfter > 0 {
+		return 0, retryAfter, nil
+	}
+
+	failures := emailFailures
+	if ipFailures > failures {
+		failures = ipFailures
+	}
+	delay = l.Config.Delay
+	for i := int64(1); i < failures && delay < l.Config.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > l.Config.MaxDelay {
+		delay = l.Config.MaxDelay
+	}
+	return delay, 0, nil
+}
+
+// Succeeded forgets failed attempts of the email. Failures of the IP are kept,
+// so that logging into an own account does not reset them.
+func (l *Login) Succeeded(ctx context.Context, email string) error {
+	return l.Redis.Del(ctx, failuresKey("email", email)).Err()
+}
+
+func (l *Login) incr(ctx context.Context, key string) (int64, error) {
+	n, err := l.Redis.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if n == 1 {
+		if err = l.Redis.Expire(ctx, key, l.Config.Window).Err(); err != nil {
+			return 0, err
+		}
+	}
+	return n, nil
+}
+
+func (l *Login) lock(ctx context.Context, kind, value string) error {
+	_, err := l.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, lockKey(kind, value), 1, l.Config.Lockout)
+		pipe.Del(ctx, failuresKey(kind, value))
+		return nil
+	})
+	return err
+}
+
+func failuresKey(kind, value string) string {
+	return "login_failures:" + kind + ":" + strings.ToLower(value)
+}
+
+func lockKey(kind, value string) string {
+	return "login_lock:" + kind + ":" + strings.ToLower(value)
+}
@@ -0,0 +1,115 @@
+package tokenstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/go-funcards/jwt"
+	"github.com/go-redis/redis/v8"
+	"strconv"
+	"time"
+)
+
+// DenyList rejects access tokens issued before a logout. Entries live as long as
+// an access token does, after that the token is expired anyway.
+type DenyList struct {
+	Redis *redis.Client
+	TTL   time.Duration
+}
+
+// Generator records when each generated access token was issued in milliseconds, as the iat claim
+// has second precision, so that tokens issued in the same second before and after DenyUser differ.
+func (d *DenyList) Generator(generator jwt.Generator) jwt.Generator {
+	return &issuedGenerator{Generator: generator, denyList: d}
+}
+
+// DenyToken rejects a single access token.
+func (d *DenyList) DenyToken(ctx context.Context, accessToken string) error {
+	return d.Redis.Set(ctx, deniedKey(tokenHash(accessToken)), 1, d.TTL).Err()
+}
+
+// denyHashes rejects access tokens by hash until they expire.
+func (d *DenyList) denyHashes(ctx context.Context, hashes map[string]time.Time) error {
+	now := time.Now()
+	_, err := d.Redis.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for hash, expiresAt := range hashes {
+			if expiresAt.After(now) {
+				pipe.Set(ctx, deniedKey(hash), 1, expiresAt.Sub(now))
+			}
+		}
+		return nil
+	})
+	return err
+}
+
+// DenyUser rejects every access token of the user issued up to now.
+func (d *DenyList) DenyUser(ctx context.Context, userID string) error {
+	return d.Redis.Set(ctx, revokedKey(userID), time.Now().UnixMilli(), d.TTL).Err()
+}
+
+func (d *DenyList) IsDenied(ctx context.Context, accessToken, userID string, issuedAt time.Time) (bool, error) {
+	hash := tokenHash(accessToken)
+	values, err := d.Redis.MGet(ctx, deniedKey(hash), revokedKey(userID), issuedKey(hash)).Result()
+	if err != nil {
+		return false, err
+	}
+	if values[0] != nil {
+		return true, nil
+	}
+	str, ok := values[1].(string)
+	if !ok {
+		return false, nil
+	}
+	revokedAt, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		return false, err
+	}
+	// revocations stored in seconds reject the whole second
+	if revokedAt < 1e12 {
+		revokedAt = revokedAt*1000 + 999
+	}
+
+	// tokens generated before issue times were recorded fall back to the iat claim
+	issued := issuedAt.UnixMilli()
+	if str, ok := values[2].(string); ok {
+		if issued, err = strconv.ParseInt(str, 10, 64); err != nil {
+			return false, err
+		}
+	}
+	return issued < revokedAt, nil
+}
+
+type issuedGenerator struct {
+	jwt.Generator
+	denyList *DenyList
+}
+
+func (g *issuedGenerator) GenerateToken(user jwt.User) (string, error) {
+	issuedAt := time.Now().UnixMilli()
+	accessToken, err := g.Generator.GenerateToken(user)
+	if err != nil {
+		return "", err
+	}
+	// the generator has no context, the record is written before the token is handed out
+	if err = g.denyList.Redis.Set(context.Background(), issuedKey(tokenHash(accessToken)), issuedAt, g.denyList.TTL).Err(); err != nil {
+		return "", err
+	}
+	return accessToken, nil
+}
+
+func tokenHash(accessToken string) string {
+	sum := sha256.Sum256([]byte(accessToken))
+	return hex.EncodeToString(sum[:])
+}
+
+func deniedKey(hash string) string {
+	return "denied_access_token:" + hash
+}
+
+func issuedKey(hash string) string {
+	return "access_token_issued:" + hash
+}
+
+func revokedKey(userID string) string {
+	return "revoked_access_tokens:" + userID
+}
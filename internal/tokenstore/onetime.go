@@ -0,0 +1,71 @@
+package tokenstore
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"github.com/go-redis/redis/v8"
+	"time"
+)
+
+// OneTime issues short-lived single-use tokens bound to a value, e.g. a user ID.
+// Only a hash of the token is stored.
+type OneTime struct {
+	Redis  *redis.Client
+	Prefix string
+	TTL    time.Duration
+}
+
+func (o *OneTime) Issue(ctx context.Context, value string) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	if err := o.Redis.Set(ctx, o.key(token), value, o.TTL).Err(); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// Consume returns the value of the token and invalidates it.
+func (o *OneTime) Consume(ctx context.Context, token string) (string, error) {
+	value, _, err := o.Claim(ctx, token)
+	return value, err
+}
+
+// Claim invalidates the token like Consume and also returns its remaining TTL, so that it can be
+// unclaimed when the operation it authorizes fails. Only one of concurrent claims gets the value.
+func (o *OneTime) Claim(ctx context.Context, token string) (string, time.Duration, error) {
+	var (
+		ttl   *redis.DurationCmd
+		value *redis.StringCmd
+	)
+	_, err := o.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		ttl = pipe.PTTL(ctx, o.key(token))
+		value = pipe.GetDel(ctx, o.key(token))
+		return nil
+	})
+	if err == redis.Nil {
+		return "", 0, ErrNotFound
+	}
+	if err != nil {
+		return "", 0, err
+	}
+	return value.Val(), ttl.Val(), nil
+}
+
+// Unclaim puts the claimed token back until it would have expired.
+func (o *OneTime) Unclaim(ctx context.Context, token, value string, ttl time.Duration) error {
+	if ttl <= 0 {
+		return nil
+	}
+	return o.Redis.SetNX(ctx, o.key(token), value, ttl).Err()
+}
+
+func (o *OneTime) key(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return o.Prefix + ":" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,183 @@
+package tokenstore
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-funcards/slice"
+	"github.com/go-funcards/token"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Session describes a device the user is logged in from. A session outlives
+// its refresh tokens, which are rotated on every refresh.
+type Session struct {
+	SessionID    string    `json:"session_id"`
+	UserID       string    `json:"user_id"`
+	RefreshToken string    `json:"refresh_token"`
+	CreatedAt    time.Time `json:"created_at"`
+	RefreshedAt  time.Time `json:"refreshed_at"`
+	UserAgent    string    `json:"user_agent"`
+	IP           string    `json:"ip"`
+	// AccessTokens maps hashes of access tokens issued to the session to their expiry,
+	// they are denied when the session is revoked
+	AccessTokens map[string]time.Time `json:"access_tokens,omitempty"`
+}
+
+type Client struct {
+	UserAgent string
+	IP        string
+}
+
+// StartSession records a new session for the tokens of a newly issued session.
+func (s *Storage) StartSession(ctx context.Context, userID string, tokens token.Session, client Client) error {
+	now := time.Now().UTC()
+	session := Session{
+		SessionID:    uuid.NewString(),
+		UserID:       userID,
+		RefreshToken: tokens.RefreshToken,
+		CreatedAt:    now,
+		RefreshedAt:  now,
+		UserAgent:    client.UserAgent,
+		IP:           client.IP,
+	}
+	s.addAccessToken(&session, tokens.AccessToken)
+	return s.saveSession(ctx, session)
+}
+
+// SessionID returns ID of the session the refresh token belongs to.
+func (s *Storage) SessionID(ctx context.Context, refreshToken string) (string, error) {
+	sessionID, err := s.Redis.Get(ctx, refreshTokenKey(refreshToken)).Result()
+	if err == redis.Nil {
+		return "", ErrNotFound
+	}
+	return sessionID, err
+}
+
+// RefreshSession moves the session to rotated tokens. A new session is started
+// when the refresh token was issued without one.
+func (s *Storage) RefreshSession(ctx context.Context, userID, sessionID string, tokens token.Session, client Client) error {
+	session, err := s.getSession(ctx, sessionID)
+	if err == ErrNotFound {
+		return s.StartSession(ctx, userID, tokens, client)
+	}
+	if err != nil {
+		return err
+	}
+	session.RefreshToken = tokens.RefreshToken
+	session.RefreshedAt = time.Now().UTC()
+	session.UserAgent = client.UserAgent
+	session.IP = client.IP
+	s.addAccessToken(&session, tokens.AccessToken)
+	return s.saveSession(ctx, session)
+}
+
+// Sessions returns active sessions of the user.
+func (s *Storage) Sessions(ctx context.Context, userID string) ([]Session, error) {
+	ids, err := s.Redis.SMembers(ctx, sessionsKey(userID)).Result()
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	values, err := s.Redis.MGet(ctx, slice.Map(ids, sessionKey)...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]Session, 0, len(ids))
+	for i, value := range values {
+		str, ok := value.(string)
+		if !ok {
+			s.Redis.SRem(ctx, sessionsKey(userID), ids[i])
+			continue
+		}
+		var session Session
+		if err = json.Unmarshal([]byte(str), &session); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+// RevokeSession deletes the session and its refresh token if it belongs to the user,
+// and denies the access tokens issued to the session.
+func (s *Storage) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	session, err := s.getSession(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session.UserID != userID {
+		return ErrNotFound
+	}
+	if err = s.delete(ctx, session.RefreshToken); err != nil {
+		return err
+	}
+	if err = s.DenyList.denyHashes(ctx, session.AccessTokens); err != nil {
+		return err
+	}
+	return s.deleteSession(ctx, session)
+}
+
+// addAccessToken records the access token issued to the session, dropping expired ones.
+func (s *Storage) addAccessToken(session *Session, accessToken string) {
+	now := time.Now().UTC()
+	tokens := make(map[string]time.Time, len(session.AccessTokens)+1)
+	for hash, expiresAt := range session.AccessTokens {
+		if expiresAt.After(now) {
+			tokens[hash] = expiresAt
+		}
+	}
+	tokens[tokenHash(accessToken)] = now.Add(s.DenyList.TTL)
+	session.AccessTokens = tokens
+}
+
+func (s *Storage) getSession(ctx context.Context, sessionID string) (Session, error) {
+	data, err := s.Redis.Get(ctx, sessionKey(sessionID)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return Session{}, ErrNotFound
+		}
+		return Session{}, err
+	}
+	var session Session
+	err = json.Unmarshal([]byte(data), &session)
+	return session, err
+}
+
+func (s *Storage) saveSession(ctx context.Context, session Session) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	_, err = s.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, sessionKey(session.SessionID), string(data), s.TTL)
+		pipe.Set(ctx, refreshTokenKey(session.RefreshToken), session.SessionID, s.TTL)
+		pipe.SAdd(ctx, sessionsKey(session.UserID), session.SessionID)
+		pipe.Expire(ctx, sessionsKey(session.UserID), s.TTL)
+		return nil
+	})
+	return err
+}
+
+func (s *Storage) deleteSession(ctx context.Context, session Session) error {
+	_, err := s.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, sessionKey(session.SessionID))
+		pipe.SRem(ctx, sessionsKey(session.UserID), session.SessionID)
+		return nil
+	})
+	return err
+}
+
+func sessionKey(sessionID string) string {
+	return "session:" + sessionID
+}
+
+func sessionsKey(userID string) string {
+	return "sessions:" + userID
+}
+
+func refreshTokenKey(refreshToken string) string {
+	return "refresh_token_session:" + refreshToken
+}
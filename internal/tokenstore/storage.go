@@ -0,0 +1,104 @@
+package tokenstore
+
+import (
+	"context"
+	"errors"
+	"github.com/go-funcards/jwt"
+	"github.com/go-funcards/slice"
+	"github.com/go-funcards/token"
+	"github.com/go-funcards/token-redis"
+	"github.com/go-redis/redis/v8"
+	"time"
+)
+
+var _ token.Storage = (*Storage)(nil)
+
+var ErrNotFound = errors.New("token not found")
+
+// Storage keeps refresh tokens in Redis like tokenredis.Storage and additionally
+// indexes them by user and session, so that sessions of a user can be listed and revoked.
+type Storage struct {
+	*tokenredis.Storage
+	TTL time.Duration
+	// DenyList denies access tokens of revoked sessions
+	DenyList *DenyList
+}
+
+func NewStorage(rdb *redis.Client, ttl time.Duration, denyList *DenyList) *Storage {
+	return &Storage{Storage: &tokenredis.Storage{Redis: rdb}, TTL: ttl, DenyList: denyList}
+}
+
+func (s *Storage) Set(ctx context.Context, refreshToken string, user jwt.User, expiration time.Duration) error {
+	if err := s.Storage.Set(ctx, refreshToken, user, expiration); err != nil {
+		return err
+	}
+	_, err := s.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.SAdd(ctx, userKey(user.UserID), refreshToken)
+		pipe.Expire(ctx, userKey(user.UserID), expiration)
+		return nil
+	})
+	return err
+}
+
+// Del deletes the refresh token, its session is kept until revoked, as the token is
+// also deleted when it is rotated by refresh.
+func (s *Storage) Del(ctx context.Context, refreshToken string) {
+	_ = s.delete(ctx, refreshToken)
+}
+
+func (s *Storage) delete(ctx context.Context, refreshToken string) error {
+	user, err := s.Get(ctx, refreshToken)
+	if err != nil && err != redis.Nil {
+		return err
+	}
+	_, err = s.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if len(user.UserID) > 0 {
+			pipe.SRem(ctx, userKey(user.UserID), refreshToken)
+		}
+		pipe.Del(ctx, refreshTokenKey(refreshToken), refreshToken)
+		return nil
+	})
+	return err
+}
+
+// Revoke deletes the refresh token and its session if it belongs to the user.
+func (s *Storage) Revoke(ctx context.Context, userID, refreshToken string) error {
+	user, err := s.Get(ctx, refreshToken)
+	if err != nil {
+		if err == redis.Nil {
+			return ErrNotFound
+		}
+		return err
+	}
+	if user.UserID != userID {
+		return ErrNotFound
+	}
+	if sessionID, err := s.SessionID(ctx, refreshToken); err == nil {
+		return s.RevokeSession(ctx, userID, sessionID)
+	}
+	s.Del(ctx, refreshToken)
+	return nil
+}
+
+// RevokeAll deletes every refresh token and session of the user.
+func (s *Storage) RevokeAll(ctx context.Context, userID string) error {
+	refreshTokens, err := s.Redis.SMembers(ctx, userKey(userID)).Result()
+	if err != nil {
+		return err
+	}
+	sessionIDs, err := s.Redis.SMembers(ctx, sessionsKey(userID)).Result()
+	if err != nil {
+		return err
+	}
+
+	keys := []string{userKey(userID), sessionsKey(userID)}
+	keys = append(keys, refreshTokens...)
+	keys = append(keys, slice.Map(refreshTokens, refreshTokenKey)...)
+	keys = append(keys, slice.Map(sessionIDs, sessionKey)...)
+
+	return s.Redis.Del(ctx, keys...).Err()
+}
+
+func userKey(userID string) string {
+	return "refresh_tokens:" + userID
+}
@@ -0,0 +1,181 @@
+package tokenstore
+
+import (
+	"context"
+	"github.com/go-redis/redis/v8"
+	"strings"
+	"time"
+)
+
+const (
+	verified   = "1"
+	unverified = "0"
+)
+
+// Verifications tracks whether the email of users is verified. The state is stored positively,
+// users without it, e.g. registered before verification was introduced or after it was lost,
+// are verified only when they registered before Since. The address a token was issued for is
+// recorded, it is the verified email once the token is confirmed.
+type Verifications struct {
+	Redis  *redis.Client
+	Tokens *OneTime
+	// Since is when email verification was introduced, zero verifies no user without a state
+	// and the state is not stored
+	Since time.Time
+	// Registered returns when the user registered
+	Registered func(ctx context.Context, userID string) (time.Time, error)
+}
+
+// confirm verifies the user unless a token for another address was issued since.
+var confirm = redis.NewScript(`
+if ARGV[1] ~= '' and redis.call('GET', KEYS[2]) ~= ARGV[1] then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[2])
+redis.call('DEL', KEYS[3])
+return 1
+`)
+
+// Snapshot is the stored verification state of a user, nil values are not stored.
+type Snapshot struct {
+	verified any
+	address  any
+}
+
+// Snapshot returns the stored verification state of the user, e.g. to restore it when changing it failed.
+func (v *Verifications) Snapshot(ctx context.Context, userID string) (Snapshot, error) {
+	values, err := v.Redis.MGet(ctx, verifiedKey(userID), addressKey(userID)).Result()
+	if err != nil {
+		return Snapshot{}, err
+	}
+	return Snapshot{verified: values[0], address: values[1]}, nil
+}
+
+// Restore stores the verification state of the user as it was in the snapshot.
+func (v *Verifications) Restore(ctx context.Context, userID string, s Snapshot) error {
+	_, err := v.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for key, value := range map[string]any{verifiedKey(userID): s.verified, addressKey(userID): s.address} {
+			if value == nil {
+				pipe.Del(ctx, key)
+			} else {
+				pipe.Set(ctx, key, value, 0)
+			}
+		}
+		return nil
+	})
+	return err
+}
+
+// MarkUnverified marks the user unverified, e.g. before it is registered or its email is changed.
+func (v *Verifications) MarkUnverified(ctx context.Context, userID string) error {
+	_, err := v.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, verifiedKey(userID), unverified, 0)
+		pipe.Del(ctx, addressKey(userID))
+		return nil
+	})
+	return err
+}
+
+// Forget deletes the state of the user, e.g. when registering it failed.
+func (v *Verifications) Forget(ctx context.Context, userID string) error {
+	return v.Redis.Del(ctx, verifiedKey(userID), addressKey(userID), unverifiedKey(userID)).Err()
+}
+
+// Issue marks the user unverified and returns a token confirming the email. Tokens
+// issued for other addresses before are not confirmed anymore.
+func (v *Verifications) Issue(ctx context.Context, userID, email string) (string, error) {
+	email = normalizeEmail(email)
+	_, err := v.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, verifiedKey(userID), unverified, 0)
+		pipe.Set(ctx, addressKey(userID), email, 0)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return v.Tokens.Issue(ctx, userID+":"+email)
+}
+
+// Confirm verifies the email of the user the token was issued for.
+func (v *Verifications) Confirm(ctx context.Context, token string) (string, error) {
+	value, err := v.Tokens.Consume(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	// tokens issued before addresses were recorded hold only the user ID
+	userID, email, _ := strings.Cut(value, ":")
+
+	ok, err := confirm.Run(ctx, v.Redis, []string{verifiedKey(userID), addressKey(userID), unverifiedKey(userID)}, email, verified).Bool()
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", ErrNotFound
+	}
+	return userID, nil
+}
+
+// VerifiedEmail returns the verified email of the user, it is empty when the user is not verified
+// or was verified before addresses were recorded.
+func (v *Verifications) VerifiedEmail(ctx context.Context, userID string) (string, error) {
+	values, err := v.Redis.MGet(ctx, verifiedKey(userID), addressKey(userID)).Result()
+	if err != nil || values[0] != verified {
+		return "", err
+	}
+	email, _ := values[1].(string)
+	return email, nil
+}
+
+func (v *Verifications) IsVerified(ctx context.Context, userID string) (bool, error) {
+	values, err := v.Redis.MGet(ctx, verifiedKey(userID), unverifiedKey(userID)).Result()
+	if err != nil {
+		return false, err
+	}
+	switch values[0] {
+	case verified:
+		return true, nil
+	case unverified:
+		return false, nil
+	}
+	// pending verification issued before the state was stored positively
+	if values[1] != nil {
+		return false, nil
+	}
+
+	// not stored, so that users registered before Since are verified once it is set
+	if v.Since.IsZero() {
+		return false, nil
+	}
+
+	registered, err := v.Registered(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	ok := registered.Before(v.Since)
+
+	state := unverified
+	if ok {
+		state = verified
+	}
+	// a concurrent Issue or Confirm wins
+	if err = v.Redis.SetNX(ctx, verifiedKey(userID), state, 0).Err(); err != nil {
+		return false, err
+	}
+	return ok, nil
+}
+
+func verifiedKey(userID string) string {
+	return "email_verified:" + userID
+}
+
+func addressKey(userID string) string {
+	return "email_verification_address:" + userID
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func unverifiedKey(userID string) string {
+	return "email_unverified:" + userID
+}
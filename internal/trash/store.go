@@ -0,0 +1,222 @@
+package trash
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/go-funcards/slice"
+	"github.com/go-redis/redis/v8"
+	"google.golang.org/protobuf/proto"
+	"strconv"
+	"time"
+)
+
+const (
+	TypeBoard    = "board"
+	TypeCategory = "category"
+	TypeCard     = "card"
+
+	expiryKey = "trash_expiry"
+	claimKey  = "trash_claimed"
+)
+
+// claim moves the entry from the purge schedule to the claimed entries, scored by the lease deadline.
+var claim = redis.NewScript(`
+if redis.call('ZREM', KEYS[1], ARGV[1]) == 0 then
+	return 0
+end
+redis.call('ZADD', KEYS[2], ARGV[2], ARGV[1])
+return 1
+`)
+
+// reclaim puts the entry back to the purge schedule, unless its lease was renewed or released meanwhile.
+var reclaim = redis.NewScript(`
+local deadline = redis.call('ZSCORE', KEYS[2], ARGV[1])
+if not deadline or tonumber(deadline) > tonumber(ARGV[3]) then
+	return 0
+end
+redis.call('ZREM', KEYS[2], ARGV[1])
+if ARGV[2] ~= '' then
+	redis.call('ZADD', KEYS[1], ARGV[2], ARGV[1])
+end
+return 1
+`)
+
+var ErrNotFound = errors.New("trash entry not found")
+
+type Config struct {
+	// Retention is how long deleted entities may be restored
+	Retention time.Duration `yaml:"retention" env:"RETENTION" env-default:"720h"`
+	// PurgeInterval is how often expired entries are purged
+	PurgeInterval time.Duration `yaml:"purge_interval" env:"PURGE_INTERVAL" env-default:"1h"`
+	// Lease is how long a claimed entry may be purged or restored, it is scheduled again afterwards
+	Lease time.Duration `yaml:"lease" env:"LEASE" env-default:"15m"`
+}
+
+// Entry is a deleted entity. Data is the serialized create request recreating it.
+type Entry struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	BoardID   string    `json:"board_id"`
+	Name      string    `json:"name"`
+	DeletedBy string    `json:"deleted_by"`
+	DeletedAt time.Time `json:"deleted_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Data      []byte    `json:"data"`
+}
+
+// Unmarshal decodes the create request recreating the entity.
+func (e Entry) Unmarshal(m proto.Message) error {
+	return proto.Unmarshal(e.Data, m)
+}
+
+// Store keeps deleted entities until the retention period expires. Entries are
+// indexed by the user who deleted them, who is the only one allowed to restore them.
+type Store struct {
+	Redis     *redis.Client
+	Retention time.Duration
+	Lease     time.Duration
+}
+
+// Put moves the entity to the trash, m is the create request recreating it.
+func (s *Store) Put(ctx context.Context, e Entry, m proto.Message) error {
+	data, err := proto.Marshal(m)
+	if err != nil {
+		return err
+	}
+	e.Data = data
+	e.DeletedAt = time.Now().UTC()
+	e.ExpiresAt = e.DeletedAt.Add(s.Retention)
+
+	value, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, entryKey(e.ID), value, 0)
+		pipe.ZAdd(ctx, userKey(e.DeletedBy), &redis.Z{Score: float64(e.DeletedAt.UnixNano()), Member: e.ID})
+		pipe.ZAdd(ctx, expiryKey, &redis.Z{Score: float64(e.ExpiresAt.Unix()), Member: e.ID})
+		return nil
+	})
+	return err
+}
+
+func (s *Store) Get(ctx context.Context, id string) (Entry, error) {
+	var e Entry
+	data, err := s.Redis.Get(ctx, entryKey(id)).Bytes()
+	if err == redis.Nil {
+		return e, ErrNotFound
+	}
+	if err != nil {
+		return e, err
+	}
+	return e, json.Unmarshal(data, &e)
+}
+
+// List returns a page of entries deleted by the user, the most recent first.
+func (s *Store) List(ctx context.Context, userID string, index uint64, size uint32) ([]Entry, uint64, error) {
+	total, err := s.Redis.ZCard(ctx, userKey(userID)).Result()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	start := int64(index) * int64(size)
+	ids, err := s.Redis.ZRevRange(ctx, userKey(userID), start, start+int64(size)-1).Result()
+	if err != nil || len(ids) == 0 {
+		return nil, uint64(total), err
+	}
+
+	values, err := s.Redis.MGet(ctx, slice.Map(ids, entryKey)...).Result()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	entries := make([]Entry, 0, len(values))
+	for _, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+		var e Entry
+		if err = json.Unmarshal([]byte(data), &e); err != nil {
+			return nil, 0, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, uint64(total), nil
+}
+
+// Claim takes the entry out of the purge schedule for the lease. Only one of concurrent
+// restores and purges claims the entry. Unless it is removed or unclaimed, Reclaim
+// schedules it again when the lease expires, e.g. after a crash.
+func (s *Store) Claim(ctx context.Context, id string) (bool, error) {
+	deadline := time.Now().Add(s.Lease).Unix()
+	return claim.Run(ctx, s.Redis, []string{expiryKey, claimKey}, id, deadline).Bool()
+}
+
+// Unclaim puts the entry back to the purge schedule, e.g. when its restore failed.
+func (s *Store) Unclaim(ctx context.Context, e Entry) error {
+	_, err := s.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRem(ctx, claimKey, e.ID)
+		pipe.ZAdd(ctx, expiryKey, &redis.Z{Score: float64(e.ExpiresAt.Unix()), Member: e.ID})
+		return nil
+	})
+	return err
+}
+
+// Reclaim puts entries whose lease expired before now back to the purge schedule.
+func (s *Store) Reclaim(ctx context.Context, now time.Time, limit int64) error {
+	ids, err := s.Redis.ZRangeByScore(ctx, claimKey, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(now.Unix(), 10),
+		Count: limit,
+	}).Result()
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		// a removed entry only leaves the lease
+		expiresAt := ""
+		e, err := s.Get(ctx, id)
+		if err == nil {
+			expiresAt = strconv.FormatInt(e.ExpiresAt.Unix(), 10)
+		} else if err != ErrNotFound {
+			return err
+		}
+		if err = reclaim.Run(ctx, s.Redis, []string{expiryKey, claimKey}, id, expiresAt, now.Unix()).Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Remove deletes the claimed entry.
+func (s *Store) Remove(ctx context.Context, e Entry) error {
+	_, err := s.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, entryKey(e.ID))
+		pipe.ZRem(ctx, userKey(e.DeletedBy), e.ID)
+		pipe.ZRem(ctx, expiryKey, e.ID)
+		pipe.ZRem(ctx, claimKey, e.ID)
+		return nil
+	})
+	return err
+}
+
+// Expired returns IDs of entries whose retention expired before now.
+func (s *Store) Expired(ctx context.Context, now time.Time, limit int64) ([]string, error) {
+	return s.Redis.ZRangeByScore(ctx, expiryKey, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(now.Unix(), 10),
+		Count: limit,
+	}).Result()
+}
+
+func entryKey(id string) string {
+	return "trash:" + id
+}
+
+func userKey(userID string) string {
+	return "trash_user:" + userID
+}